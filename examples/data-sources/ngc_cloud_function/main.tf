@@ -2,3 +2,8 @@ data "ngc_cloud_function" "terraform-cloud-function-datasource-example" {
   function_id = "98370588-40c4-4369-b965-12679ce05f47"
   version_id  = "59a6193e-d0ed-4abb-8f47-7dd46480f126"
 }
+
+data "ngc_cloud_function" "terraform-cloud-function-latest-active-datasource-example" {
+  function_name    = "terraform-cloud-function-resource-example-helm"
+  version_selector = "latest_active"
+}
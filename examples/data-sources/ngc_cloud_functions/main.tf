@@ -0,0 +1,5 @@
+data "ngc_cloud_functions" "terraform-cloud-functions-datasource-example" {
+  name_regex = "^terraform-.*"
+  tags       = ["example"]
+  status     = "ACTIVE"
+}
@@ -0,0 +1,7 @@
+output "function_ids" {
+  value = data.ngc_cloud_functions.terraform-cloud-functions-datasource-example.functions[*].function_id
+}
+
+output "function_version_ids" {
+  value = data.ngc_cloud_functions.terraform-cloud-functions-datasource-example.functions[*].version_id
+}
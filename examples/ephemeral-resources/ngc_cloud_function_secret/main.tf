@@ -0,0 +1,26 @@
+variable "database_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+variable "s3_secret_access_key" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+ephemeral "ngc_cloud_function_secret" "database_password_example" {
+  name  = "DB_PASSWORD"
+  value = var.database_password
+}
+
+# Sent to NVCF as the json node
+# {"AWS_ACCESS_KEY_ID": "...", "AWS_SECRET_ACCESS_KEY": "..."}
+ephemeral "ngc_cloud_function_secret" "s3_credentials_example" {
+  name = "test.s3.us-west-2.amazonaws.com"
+  values = {
+    AWS_ACCESS_KEY_ID     = "s3.us-west-2-key-id"
+    AWS_SECRET_ACCESS_KEY = var.s3_secret_access_key
+  }
+}
@@ -11,3 +11,23 @@ provider "ngc" {
   ngc_org     = "shhh2i6mga69"   # Can be replace with `NGC_ORG` environment variable.
   ngc_team    = "devinfra"       # Can be replace with `NGC_TEAM` environment variable.
 }
+
+# Read the API key, org and team from the `staging` profile of the NGC CLI config file `~/.ngc/config`.
+provider "ngc" {
+  alias   = "staging"
+  profile = "staging" # Can be replaced with `NGC_CLI_PROFILE` environment variable.
+}
+
+# Authenticate with short-lived access tokens of a service account instead of a personal key.
+provider "ngc" {
+  alias   = "service_account"
+  ngc_org = "shhh2i6mga69"
+
+  auth {
+    type          = "client_credentials"
+    client_id     = "nvssa-prd-REDACTED"        # Can be replaced with `NGC_AUTH_CLIENT_ID` environment variable.
+    client_secret = "nvssa-prd-secret-REDACTED" # Can be replaced with `NGC_AUTH_CLIENT_SECRET` environment variable.
+    token_url     = "https://REDACTED.ssa.nvidia.com/token"
+    scopes        = ["list_functions", "deploy_function"]
+  }
+}
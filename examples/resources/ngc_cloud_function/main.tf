@@ -42,7 +42,12 @@ resource "ngc_cloud_function" "helm_based_cloud_function_example_version" {
   api_body_format         = "CUSTOM"
   deployment_specifications = [
     {
-      configuration           = "{\"image\":{\"repository\":\"nvcr.io/shhh2i6mga69/devinfra/fastapi_echo_sample\",\"tag\":\"latest\"}}",
+      # Helm values can be given as YAML as well as JSON.
+      configuration           = <<-EOT
+        image:
+          repository: nvcr.io/shhh2i6mga69/devinfra/fastapi_echo_sample
+          tag: latest
+      EOT
       backend                 = "dgxc-forge-az33-prd1"
       instance_type           = "DGX-CLOUD.GPU.L40_1x"
       gpu_type                = "L40"
@@ -194,3 +199,133 @@ resource "ngc_cloud_function" "container_based_cloud_function_example_version" {
     "test"
   ]
 }
+
+resource "ngc_cloud_function" "blue_green_cloud_function_example" {
+  function_name   = "terraform-cloud-function-resource-example-blue-green"
+  container_image = "nvcr.io/shhh2i6mga69/devinfra/fastapi_echo_sample:latest"
+  inference_port  = 8000
+  inference_url   = "/echo"
+  api_body_format = "CUSTOM"
+  # A new container_image is deployed as a new version of the same function,
+  # the old version is deleted once the new one is ACTIVE.
+  rollout_strategy = "blue_green"
+  # The partners are authorized to invoke the function, whatever its version,
+  # and keep their access while a rollout replaces the version.
+  authorized_parties = [
+    {
+      nca_id = "partner-nca-id"
+    }
+  ]
+  authorized_parties_scope = "function"
+  deployment_specifications = [
+    {
+      backend                 = "dgxc-forge-az33-prd1"
+      instance_type           = "DGX-CLOUD.GPU.L40_1x"
+      gpu_type                = "L40"
+      max_instances           = 1
+      min_instances           = 1
+      max_request_concurrency = 1
+    }
+  ]
+  health = {
+    uri                  = "/health"
+    port                 = 8000
+    expected_status_code = 200
+    timeout              = "PT10S"
+    protocol             = "HTTP"
+  }
+  timeouts = {
+    update = "30m"
+  }
+}
+
+resource "ngc_cloud_function" "canary_cloud_function_example" {
+  function_name   = "terraform-cloud-function-resource-example-canary"
+  container_image = "nvcr.io/shhh2i6mga69/devinfra/fastapi_echo_sample:latest"
+  inference_port  = 8000
+  inference_url   = "/echo"
+  api_body_format = "CUSTOM"
+  # A new container_image is deployed as a new version next to the old one,
+  # which gets 10% then 50% of the capacity, checking its health 5 minutes
+  # after each step.
+  rollout_strategy = "canary"
+  canary {
+    steps = [10, 50]
+    pause = "5m"
+  }
+  deployment_specifications = [
+    {
+      backend                 = "dgxc-forge-az33-prd1"
+      instance_type           = "DGX-CLOUD.GPU.L40_1x"
+      gpu_type                = "L40"
+      max_instances           = 10
+      min_instances           = 2
+      max_request_concurrency = 1
+    }
+  ]
+  health = {
+    uri                  = "/health"
+    port                 = 8000
+    expected_status_code = 200
+    timeout              = "PT10S"
+    protocol             = "HTTP"
+  }
+  timeouts = {
+    update = "1h"
+  }
+}
+
+resource "ngc_cloud_function" "multi_backend_cloud_function_example" {
+  function_name   = "terraform-cloud-function-resource-example-multi-backend"
+  container_image = "nvcr.io/shhh2i6mga69/devinfra/fastapi_echo_sample:latest"
+  inference_port  = 8000
+  inference_url   = "/echo"
+  api_body_format = "CUSTOM"
+  # Deployments keyed by backend, the plan shows which backend is added,
+  # removed or scaled.
+  deployments = {
+    "dgxc-forge-az33-prd1" = {
+      instance_type           = "DGX-CLOUD.GPU.L40_1x"
+      gpu_type                = "L40"
+      max_instances           = 2
+      min_instances           = 1
+      max_request_concurrency = 1
+    }
+    "GFN" = {
+      instance_type           = "gl40_1.br20_2xlarge"
+      gpu_type                = "L40"
+      max_instances           = 1
+      min_instances           = 1
+      max_request_concurrency = 1
+    }
+  }
+  health = {
+    uri                  = "/health"
+    port                 = 8000
+    expected_status_code = 200
+    timeout              = "PT10S"
+    protocol             = "HTTP"
+  }
+}
+
+resource "ngc_cloud_function" "write_only_secrets_cloud_function_example" {
+  function_name   = "terraform-cloud-function-resource-example-write-only-secrets"
+  container_image = "nvcr.io/shhh2i6mga69/devinfra/fastapi_echo_sample:latest"
+  inference_port  = 8000
+  inference_url   = "/echo"
+  api_body_format = "CUSTOM"
+  # Write-only secrets are never stored in the state, bump
+  # secrets_wo_version to rotate them. Requires Terraform 1.11 or later.
+  secrets_wo = merge(
+    ephemeral.ngc_cloud_function_secret.database_password_example.secret,
+    ephemeral.ngc_cloud_function_secret.s3_credentials_example.secret,
+  )
+  secrets_wo_version = 1
+  health = {
+    uri                  = "/health"
+    port                 = 8000
+    expected_status_code = 200
+    timeout              = "PT10S"
+    protocol             = "HTTP"
+  }
+}
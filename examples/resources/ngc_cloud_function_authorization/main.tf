@@ -0,0 +1,40 @@
+resource "ngc_cloud_function" "container_based_cloud_function_example" {
+  function_name   = "terraform-cloud-function-authorization-example-container"
+  container_image = "nvcr.io/shhh2i6mga69/devinfra/fastapi_echo_sample:latest"
+  inference_port  = 8000
+  inference_url   = "/echo"
+  api_body_format = "CUSTOM"
+  health = {
+    uri                  = "/health"
+    port                 = 8000
+    expected_status_code = 200
+    timeout              = "PT10S"
+    protocol             = "HTTP"
+  }
+}
+
+# Authorize parties to invoke a specific version of the function
+resource "ngc_cloud_function_authorization" "version_authorization_example" {
+  function_id = ngc_cloud_function.container_based_cloud_function_example.id
+  version_id  = ngc_cloud_function.container_based_cloud_function_example.version_id
+  authorized_parties = [
+    {
+      nca_id = "partner-nca-id"
+    },
+    {
+      nca_id    = "other-partner-nca-id"
+      client_id = "other-partner-client-id"
+    }
+  ]
+}
+
+# Authorize parties to invoke the function, whatever its version
+resource "ngc_cloud_function_authorization" "function_authorization_example" {
+  function_id = ngc_cloud_function.container_based_cloud_function_example.id
+  scope       = "function"
+  authorized_parties = [
+    {
+      nca_id = "partner-nca-id"
+    }
+  ]
+}
@@ -0,0 +1,34 @@
+resource "ngc_cloud_function" "helm_based_cloud_function_example" {
+  function_name           = "terraform-cloud-function-deployment-example-helm"
+  helm_chart              = "https://helm.ngc.nvidia.com/shhh2i6mga69/devinfra/charts/inference-test-0.1.tgz"
+  helm_chart_service_name = "entrypoint"
+  inference_port          = 8000
+  inference_url           = "/echo"
+  api_body_format         = "CUSTOM"
+  health = {
+    uri                  = "/health"
+    port                 = 8000
+    expected_status_code = 200
+    timeout              = "PT10S"
+    protocol             = "HTTP"
+  }
+}
+
+resource "ngc_cloud_function_deployment" "helm_based_cloud_function_deployment_example" {
+  function_id = ngc_cloud_function.helm_based_cloud_function_example.id
+  version_id  = ngc_cloud_function.helm_based_cloud_function_example.version_id
+  deployment_specifications = [
+    {
+      configuration           = "{\"image\":{\"repository\":\"nvcr.io/shhh2i6mga69/devinfra/fastapi_echo_sample\",\"tag\":\"latest\"}}",
+      backend                 = "dgxc-forge-az33-prd1"
+      instance_type           = "DGX-CLOUD.GPU.L40_1x"
+      gpu_type                = "L40"
+      max_instances           = 2
+      min_instances           = 1
+      max_request_concurrency = 1
+    }
+  ]
+  timeouts = {
+    create = "30m"
+  }
+}
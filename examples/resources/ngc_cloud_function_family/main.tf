@@ -0,0 +1,27 @@
+# Own the function, keeping up to 2 of the old versions rollouts could not delete
+resource "ngc_cloud_function_family" "cloud_function_family_example" {
+  function_name = "terraform-cloud-function-family-example"
+  initial_version = {
+    container_image = "nvcr.io/shhh2i6mga69/devinfra/fastapi_echo_sample:latest"
+    inference_port  = 8000
+    inference_url   = "/echo"
+  }
+  retained_versions = 2
+}
+
+# Manage a version of the function, which is deleted on destroy while the function is kept
+resource "ngc_cloud_function" "cloud_function_family_version_example" {
+  function_id     = ngc_cloud_function_family.cloud_function_family_example.id
+  function_name   = ngc_cloud_function_family.cloud_function_family_example.function_name
+  container_image = "nvcr.io/shhh2i6mga69/devinfra/fastapi_echo_sample:latest"
+  inference_port  = 8000
+  inference_url   = "/echo"
+  api_body_format = "CUSTOM"
+  health = {
+    uri                  = "/health"
+    port                 = 8000
+    expected_status_code = 200
+    timeout              = "PT10S"
+    protocol             = "HTTP"
+  }
+}
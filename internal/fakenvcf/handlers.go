@@ -0,0 +1,568 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package fakenvcf
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+const (
+	defaultHealthURI     = "/v2/health/ready"
+	defaultHealthTimeout = "PT10S"
+)
+
+// version returns the function version, nil when missing. The caller must hold the lock.
+func (s *Server) version(functionID string, versionID string) *version {
+	f, ok := s.functions[functionID]
+	if !ok {
+		return nil
+	}
+	return f.versions[versionID]
+}
+
+func (f *function) sortedVersions() []*version {
+	versions := make([]*version, 0, len(f.versions))
+	for _, v := range f.versions {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].info.CreatedAt.Before(versions[j].info.CreatedAt)
+	})
+	return versions
+}
+
+// lookupVersion replies with a not found error when the function version is missing.
+func (s *Server) lookupVersion(ctx *requestContext, functionID string, versionID string) *version {
+	v := s.version(functionID, versionID)
+	if v == nil {
+		ctx.notFound(fmt.Sprintf("Function '%s' version '%s' not found", functionID, versionID))
+	}
+	return v
+}
+
+// statusAt returns the deployment status, simulating the DEPLOYING to ACTIVE transition.
+func (d *deployment) statusAt(now time.Time, deploymentDuration time.Duration) string {
+	if d.status != "" {
+		return d.status
+	}
+	if now.Sub(d.updatedAt) < deploymentDuration {
+		return StatusDeploying
+	}
+	return StatusActive
+}
+
+// instanceSpecificationsAt returns the specifications of the running instances, the previous ones while scaling.
+func (d *deployment) instanceSpecificationsAt(now time.Time, deploymentDuration time.Duration) []utils.NvidiaCloudFunctionDeploymentSpecification {
+	if d.scaledFrom != nil && now.Sub(d.scaledAt) < deploymentDuration {
+		return d.scaledFrom
+	}
+	return d.specifications
+}
+
+// functionInfo returns the version as reported by the API, with its current status and instances.
+func (s *Server) functionInfo(v *version) utils.NvidiaCloudFunctionInfo {
+	info := v.info
+	info.Status = StatusInactive
+	info.ActiveInstances = nil
+
+	if v.deployment == nil {
+		return info
+	}
+
+	info.Status = v.deployment.statusAt(time.Now(), s.config.DeploymentDuration)
+
+	instanceStatus := info.Status
+	if info.Status == StatusDeploying {
+		instanceStatus = "PENDING"
+	}
+	for _, spec := range v.deployment.instanceSpecificationsAt(time.Now(), s.config.DeploymentDuration) {
+		for i := 0; i < spec.MinInstances; i++ {
+			info.ActiveInstances = append(info.ActiveInstances, utils.NvidiaCloudFunctionActiveInstance{
+				InstanceID:        fmt.Sprintf("%s-%s-%d", info.VersionID, spec.Backend, i),
+				FunctionID:        info.ID,
+				FunctionVersionID: info.VersionID,
+				InstanceType:      spec.InstanceType,
+				InstanceStatus:    instanceStatus,
+				NcaID:             info.NcaID,
+				Gpu:               spec.Gpu,
+				Backend:           spec.Backend,
+				InstanceCreatedAt: v.deployment.updatedAt,
+				InstanceUpdatedAt: v.deployment.updatedAt,
+			})
+		}
+	}
+	return info
+}
+
+func (s *Server) deploymentInfo(v *version) utils.NvidiaCloudFunctionDeployment {
+	return utils.NvidiaCloudFunctionDeployment{
+		FunctionID:               v.info.ID,
+		FunctionVersionID:        v.info.VersionID,
+		NcaID:                    v.info.NcaID,
+		FunctionStatus:           v.deployment.statusAt(time.Now(), s.config.DeploymentDuration),
+		DeploymentSpecifications: v.deployment.specifications,
+	}
+}
+
+func (s *Server) authorizationInfo(functionID string, versionID string, parties []utils.AuthorizedParty) utils.AuthorizeAccountsToInvokeFunctionResponse {
+	return utils.AuthorizeAccountsToInvokeFunctionResponse{
+		Function: utils.AuthorizeAccountsToInvokeFunctionResponseFunctionInfo{
+			Id:                functionID,
+			NcaID:             s.config.NcaID,
+			VersionID:         versionID,
+			AuthorizedParties: append([]utils.AuthorizedParty{}, parties...),
+		},
+	}
+}
+
+func validateFunction(request utils.CreateNvidiaCloudFunctionRequest) string {
+	switch {
+	case request.FunctionName == "":
+		return "name must not be empty"
+	case request.InferenceUrl == "":
+		return "inferenceUrl must not be empty"
+	case request.ContainerImage == "" && request.HelmChart == "":
+		return "one of containerImage and helmChart must be set"
+	case request.ContainerImage != "" && request.HelmChart != "":
+		return "containerImage and helmChart are mutually exclusive"
+	case request.HelmChart != "" && request.HelmChartServiceName == "":
+		return "helmChartServiceName is required with helmChart"
+	}
+
+	for _, secret := range request.Secrets {
+		if secret.Name == "" || secret.Value == nil {
+			return "secrets must have a name and a value"
+		}
+	}
+	return ""
+}
+
+func (s *Server) createFunction(ctx *requestContext, functionID string) {
+	var request utils.CreateNvidiaCloudFunctionRequest
+	if !ctx.decode(&request) {
+		return
+	}
+
+	if detail := validateFunction(request); detail != "" {
+		ctx.badRequest(detail)
+		return
+	}
+
+	f, ok := s.functions[functionID]
+	if functionID != "" && !ok {
+		ctx.notFound(fmt.Sprintf("Function '%s' not found", functionID))
+		return
+	}
+	if !ok {
+		f = &function{id: uuid.New().String(), versions: make(map[string]*version)}
+		s.functions[f.id] = f
+	}
+
+	info := utils.NvidiaCloudFunctionInfo{
+		ID:                   f.id,
+		NcaID:                s.config.NcaID,
+		VersionID:            uuid.New().String(),
+		Name:                 request.FunctionName,
+		InferenceURL:         request.InferenceUrl,
+		InferencePort:        request.InferencePort,
+		ContainerImage:       request.ContainerImage,
+		ContainerEnvironment: request.ContainerEnvironment,
+		Models:               request.Models,
+		ContainerArgs:        request.ContainerArgs,
+		APIBodyFormat:        request.APIBodyFormat,
+		HelmChart:            request.HelmChart,
+		HelmChartServiceName: request.HelmChartServiceName,
+		HealthURI:            request.HealthUri,
+		CreatedAt:            time.Now().UTC(),
+		Description:          request.Description,
+		Health:               request.Health,
+		Resources:            request.Resources,
+		Tags:                 request.Tags,
+		FunctionType:         request.FunctionType,
+	}
+
+	if info.APIBodyFormat == "" {
+		info.APIBodyFormat = "CUSTOM"
+	}
+	if info.FunctionType == "" {
+		info.FunctionType = "DEFAULT"
+	}
+	// NVCF describes a function with its name unless given a description.
+	if info.Description == "" {
+		info.Description = info.Name
+	}
+	// The legacy healthUri is turned into a health definition, and the other way around.
+	if info.Health == nil {
+		healthURI := info.HealthURI
+		if healthURI == "" {
+			healthURI = defaultHealthURI
+		}
+		info.Health = &utils.NvidiaCloudFunctionHealth{
+			Protocol:           "HTTP",
+			URI:                healthURI,
+			Port:               info.InferencePort,
+			Timeout:            defaultHealthTimeout,
+			ExpectedStatusCode: http.StatusOK,
+		}
+	}
+	if info.HealthURI == "" {
+		info.HealthURI = info.Health.URI
+	}
+
+	v := &version{
+		info:    info,
+		secrets: make(map[string]interface{}),
+	}
+	for _, secret := range request.Secrets {
+		v.secrets[secret.Name] = secret.Value
+		v.info.Secrets = append(v.info.Secrets, secret.Name)
+	}
+	f.versions[info.VersionID] = v
+
+	ctx.writeJSON(http.StatusOK, utils.CreateNvidiaCloudFunctionResponse{Function: s.functionInfo(v)})
+}
+
+func (s *Server) listFunctions(ctx *requestContext) {
+	// Every function is owned by the org, so only the private visibility matches.
+	visibility := ctx.r.URL.Query()["visibility"]
+	private := len(visibility) == 0
+	for _, v := range visibility {
+		if v == "private" {
+			private = true
+		}
+	}
+
+	response := utils.ListNvidiaCloudFunctionsResponse{Functions: []utils.NvidiaCloudFunctionInfo{}}
+	if private {
+		for _, f := range s.functions {
+			for _, v := range f.sortedVersions() {
+				response.Functions = append(response.Functions, s.functionInfo(v))
+			}
+		}
+	}
+
+	ctx.writeJSON(http.StatusOK, response)
+}
+
+func (s *Server) listFunctionVersions(ctx *requestContext, functionID string) {
+	f, ok := s.functions[functionID]
+	if !ok {
+		ctx.notFound(fmt.Sprintf("Function '%s' not found", functionID))
+		return
+	}
+
+	response := utils.ListNvidiaCloudFunctionVersionsResponse{Functions: []utils.NvidiaCloudFunctionInfo{}}
+	for _, v := range f.sortedVersions() {
+		response.Functions = append(response.Functions, s.functionInfo(v))
+	}
+
+	ctx.writeJSON(http.StatusOK, response)
+}
+
+func (s *Server) getFunctionVersion(ctx *requestContext, functionID string, versionID string) {
+	v := s.lookupVersion(ctx, functionID, versionID)
+	if v == nil {
+		return
+	}
+
+	ctx.writeJSON(http.StatusOK, utils.GetNvidiaCloudFunctionVersionResponse{Function: s.functionInfo(v)})
+}
+
+// deleteFunctionVersion also removes the deployment of the version, and the function with its last version.
+func (s *Server) deleteFunctionVersion(ctx *requestContext, functionID string, versionID string) {
+	if s.lookupVersion(ctx, functionID, versionID) == nil {
+		return
+	}
+
+	f := s.functions[functionID]
+	delete(f.versions, versionID)
+	if len(f.versions) == 0 {
+		delete(s.functions, functionID)
+	}
+
+	ctx.w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) updateMetadata(ctx *requestContext, functionID string, versionID string) {
+	v := s.lookupVersion(ctx, functionID, versionID)
+	if v == nil {
+		return
+	}
+
+	var request utils.UpdateNvidiaCloudFunctionMetadataRequest
+	if !ctx.decode(&request) {
+		return
+	}
+	v.info.Tags = request.Tags
+
+	ctx.writeJSON(http.StatusOK, utils.UpdateNvidiaCloudFunctionMetadataResponse{Function: s.functionInfo(v)})
+}
+
+func (s *Server) updateSecrets(ctx *requestContext, functionID string, versionID string) {
+	v := s.lookupVersion(ctx, functionID, versionID)
+	if v == nil {
+		return
+	}
+
+	var request utils.UpdateNvidiaCloudFunctionSecretsRequest
+	if !ctx.decode(&request) {
+		return
+	}
+	for _, secret := range request.Secrets {
+		if secret.Name == "" || secret.Value == nil {
+			ctx.badRequest("secrets must have a name and a value")
+			return
+		}
+	}
+
+	v.secrets = make(map[string]interface{})
+	v.info.Secrets = nil
+	for _, secret := range request.Secrets {
+		v.secrets[secret.Name] = secret.Value
+		v.info.Secrets = append(v.info.Secrets, secret.Name)
+	}
+
+	ctx.w.WriteHeader(http.StatusNoContent)
+}
+
+func validateDeploymentSpecifications(specifications []utils.NvidiaCloudFunctionDeploymentSpecification) string {
+	if len(specifications) == 0 {
+		return "deploymentSpecifications must not be empty"
+	}
+
+	for _, spec := range specifications {
+		switch {
+		case spec.Gpu == "":
+			return "gpu must not be empty"
+		case spec.InstanceType == "":
+			return "instanceType must not be empty"
+		case spec.MinInstances < 0:
+			return "minInstances must not be negative"
+		case spec.MaxInstances < 1:
+			return "maxInstances must be at least 1"
+		case spec.MinInstances > spec.MaxInstances:
+			return fmt.Sprintf("minInstances %d must not be greater than maxInstances %d", spec.MinInstances, spec.MaxInstances)
+		case spec.MaxRequestConcurrency < 1:
+			return "maxRequestConcurrency must be at least 1"
+		}
+	}
+	return ""
+}
+
+func (s *Server) getDeployment(ctx *requestContext, functionID string, versionID string) {
+	v := s.lookupVersion(ctx, functionID, versionID)
+	if v == nil {
+		return
+	}
+	if v.deployment == nil {
+		ctx.notFound("failed to find function deployment")
+		return
+	}
+
+	ctx.writeJSON(http.StatusOK, utils.ReadNvidiaCloudFunctionDeploymentResponse{Deployment: s.deploymentInfo(v)})
+}
+
+func (s *Server) createDeployment(ctx *requestContext, functionID string, versionID string) {
+	v := s.lookupVersion(ctx, functionID, versionID)
+	if v == nil {
+		return
+	}
+
+	var request utils.CreateNvidiaCloudFunctionDeploymentRequest
+	if !ctx.decode(&request) {
+		return
+	}
+	if detail := validateDeploymentSpecifications(request.DeploymentSpecifications); detail != "" {
+		ctx.badRequest(detail)
+		return
+	}
+	if v.deployment != nil {
+		ctx.conflict(fmt.Sprintf("Function '%s' version '%s' is already deployed", functionID, versionID))
+		return
+	}
+
+	v.deployment = &deployment{
+		specifications: request.DeploymentSpecifications,
+		updatedAt:      time.Now(),
+	}
+
+	ctx.writeJSON(http.StatusOK, utils.CreateNvidiaCloudFunctionDeploymentResponse{Deployment: s.deploymentInfo(v)})
+}
+
+func (s *Server) updateDeployment(ctx *requestContext, functionID string, versionID string) {
+	v := s.lookupVersion(ctx, functionID, versionID)
+	if v == nil {
+		return
+	}
+
+	var request utils.UpdateNvidiaCloudFunctionDeploymentRequest
+	if !ctx.decode(&request) {
+		return
+	}
+	if detail := validateDeploymentSpecifications(request.DeploymentSpecifications); detail != "" {
+		ctx.badRequest(detail)
+		return
+	}
+	if v.deployment == nil {
+		ctx.notFound("failed to find function deployment")
+		return
+	}
+
+	// Scaling an ACTIVE deployment is applied in place, keeping its instances until the scaling completes,
+	// other changes redeploy it.
+	now := time.Now()
+	updated := &deployment{
+		specifications: request.DeploymentSpecifications,
+		updatedAt:      now,
+	}
+	if v.deployment.statusAt(now, s.config.DeploymentDuration) == StatusActive && isScaling(v.deployment.specifications, request.DeploymentSpecifications) {
+		updated.updatedAt = v.deployment.updatedAt
+		updated.status = v.deployment.status
+		updated.scaledFrom = v.deployment.instanceSpecificationsAt(now, s.config.DeploymentDuration)
+		updated.scaledAt = now
+	}
+	v.deployment = updated
+
+	ctx.writeJSON(http.StatusOK, utils.UpdateNvidiaCloudFunctionDeploymentResponse{Deployment: s.deploymentInfo(v)})
+}
+
+// isScaling reports whether the specifications only differ by their instance counts and concurrency.
+func isScaling(current []utils.NvidiaCloudFunctionDeploymentSpecification, updated []utils.NvidiaCloudFunctionDeploymentSpecification) bool {
+	if len(current) != len(updated) {
+		return false
+	}
+
+	for i := range current {
+		if current[i].Gpu != updated[i].Gpu ||
+			current[i].Backend != updated[i].Backend ||
+			current[i].InstanceType != updated[i].InstanceType ||
+			!reflect.DeepEqual(current[i].Configuration, updated[i].Configuration) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) deleteDeployment(ctx *requestContext, functionID string, versionID string) {
+	v := s.lookupVersion(ctx, functionID, versionID)
+	if v == nil {
+		return
+	}
+	if v.deployment == nil {
+		ctx.notFound("failed to find function deployment")
+		return
+	}
+
+	v.deployment = nil
+
+	ctx.writeJSON(http.StatusOK, utils.DeleteNvidiaCloudFunctionDeploymentResponse{Function: s.functionInfo(v)})
+}
+
+// lookupAuthorizedParties returns the parties authorized to invoke the function version, or the function itself
+// when versionID is empty, replying with a not found error when missing.
+func (s *Server) lookupAuthorizedParties(ctx *requestContext, functionID string, versionID string) *[]utils.AuthorizedParty {
+	if versionID != "" {
+		v := s.lookupVersion(ctx, functionID, versionID)
+		if v == nil {
+			return nil
+		}
+		return &v.authorizedParties
+	}
+
+	f, ok := s.functions[functionID]
+	if !ok {
+		ctx.notFound(fmt.Sprintf("Function '%s' not found", functionID))
+		return nil
+	}
+	return &f.authorizedParties
+}
+
+func (s *Server) getAuthorization(ctx *requestContext, functionID string, versionID string) {
+	parties := s.lookupAuthorizedParties(ctx, functionID, versionID)
+	if parties == nil {
+		return
+	}
+
+	ctx.writeJSON(http.StatusOK, s.authorizationInfo(functionID, versionID, *parties))
+}
+
+// authorize replaces the parties authorized to invoke the version, or the function when versionID is empty.
+func (s *Server) authorize(ctx *requestContext, functionID string, versionID string) {
+	parties := s.lookupAuthorizedParties(ctx, functionID, versionID)
+	if parties == nil {
+		return
+	}
+
+	var request utils.AuthorizeAccountsToInvokeFunctionRequest
+	if !ctx.decode(&request) {
+		return
+	}
+	for _, party := range request.AuthorizedParties {
+		if party.NcaID == "" {
+			ctx.badRequest("authorizedParties must have an ncaId")
+			return
+		}
+	}
+	*parties = request.AuthorizedParties
+
+	ctx.writeJSON(http.StatusOK, s.authorizationInfo(functionID, versionID, *parties))
+}
+
+func (s *Server) unauthorize(ctx *requestContext, functionID string, versionID string) {
+	parties := s.lookupAuthorizedParties(ctx, functionID, versionID)
+	if parties == nil {
+		return
+	}
+	*parties = nil
+
+	ctx.writeJSON(http.StatusOK, s.authorizationInfo(functionID, versionID, *parties))
+}
+
+// updateAuthorizedParty adds or removes a single authorized party, keeping the others.
+func (s *Server) updateAuthorizedParty(ctx *requestContext, functionID string, versionID string, operation string) {
+	parties := s.lookupAuthorizedParties(ctx, functionID, versionID)
+	if parties == nil {
+		return
+	}
+
+	var request utils.AuthorizedPartyRequest
+	if !ctx.decode(&request) {
+		return
+	}
+	if request.AuthorizedParty.NcaID == "" {
+		ctx.badRequest("authorizedParty must have an ncaId")
+		return
+	}
+
+	index := slices.Index(*parties, request.AuthorizedParty)
+	switch {
+	case operation == "add" && index >= 0:
+		ctx.badRequest(fmt.Sprintf("Party '%s' is already authorized", request.AuthorizedParty.NcaID))
+		return
+	case operation == "add":
+		*parties = append(*parties, request.AuthorizedParty)
+	case index < 0:
+		ctx.badRequest(fmt.Sprintf("Party '%s' is not authorized", request.AuthorizedParty.NcaID))
+		return
+	default:
+		*parties = slices.Delete(*parties, index, index+1)
+	}
+
+	ctx.writeJSON(http.StatusOK, s.authorizationInfo(functionID, versionID, *parties))
+}
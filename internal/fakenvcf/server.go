@@ -0,0 +1,427 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+// Package fakenvcf is an in-process fake of the NVCF API, covering the
+// endpoints used by the provider, so that the tests run without NGC
+// credentials or a GPU backend.
+package fakenvcf
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+const (
+	DefaultAPIKey = "nvapi-fakenvcf"
+	DefaultOrg    = "fakenvcf-org"
+	DefaultNcaID  = "fakenvcf-nca-id"
+)
+
+// Function and deployment statuses reported by the server.
+const (
+	StatusInactive  = "INACTIVE"
+	StatusDeploying = "DEPLOYING"
+	StatusActive    = "ACTIVE"
+	StatusError     = "ERROR"
+)
+
+// Config configures a Server, zero fields fall back to the defaults.
+type Config struct {
+	// APIKey is the only Bearer token accepted.
+	APIKey string
+	// Org is the only org accepted in the request paths.
+	Org string
+	// NcaID is the NVIDIA Cloud Account owning the functions.
+	NcaID string
+	// DeploymentDuration is how long a created or updated deployment stays
+	// DEPLOYING before being ACTIVE, and how long a scaled ACTIVE deployment
+	// keeps its previous instances. With zero, it is ACTIVE on the first read.
+	DeploymentDuration time.Duration
+}
+
+// Fault makes the server reply to the matching requests with an error.
+type Fault struct {
+	// Method matches the request method, any method when empty.
+	Method string
+	// Path matches the end of the request path, such as "/nvcf/functions", any path when empty.
+	Path string
+	// StatusCode is the HTTP status code of the error.
+	StatusCode int
+	// Times is the number of requests failing, every request when zero.
+	Times int
+	// ProblemDetails replies with the problem details error format instead of the requestStatus one.
+	ProblemDetails bool
+	// Header is added to the error response, such as Retry-After.
+	Header http.Header
+}
+
+// Server is an in-process fake NVCF API. It is safe for concurrent use.
+type Server struct {
+	config Config
+	server *httptest.Server
+
+	mu        sync.Mutex
+	functions map[string]*function
+	faults    []*Fault
+}
+
+type function struct {
+	id                string
+	versions          map[string]*version
+	authorizedParties []utils.AuthorizedParty
+}
+
+type version struct {
+	info              utils.NvidiaCloudFunctionInfo
+	secrets           map[string]interface{}
+	deployment        *deployment
+	authorizedParties []utils.AuthorizedParty
+}
+
+type deployment struct {
+	specifications []utils.NvidiaCloudFunctionDeploymentSpecification
+	updatedAt      time.Time
+	// status overrides the simulated DEPLOYING to ACTIVE transition when set.
+	status string
+	// scaledFrom are the specifications whose instances run until the scaling started at scaledAt completes.
+	scaledFrom []utils.NvidiaCloudFunctionDeploymentSpecification
+	scaledAt   time.Time
+}
+
+// NewServer starts a Server, to be closed with Close.
+func NewServer(config Config) *Server {
+	if config.APIKey == "" {
+		config.APIKey = DefaultAPIKey
+	}
+	if config.Org == "" {
+		config.Org = DefaultOrg
+	}
+	if config.NcaID == "" {
+		config.NcaID = DefaultNcaID
+	}
+
+	s := &Server{
+		config:    config,
+		functions: make(map[string]*function),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL is the NGC endpoint of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+func (s *Server) Config() Config {
+	return s.config
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// InjectFault makes the server fail the requests matching the fault.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// SetDeploymentStatus forces the status of a deployment, such as StatusError
+// to simulate a failed deployment. An empty status resumes the simulated transition.
+func (s *Server) SetDeploymentStatus(functionID string, versionID string, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := s.version(functionID, versionID)
+	if v == nil || v.deployment == nil {
+		return fmt.Errorf("no deployment for function %s version %s", functionID, versionID)
+	}
+	v.deployment.status = status
+	return nil
+}
+
+// FunctionVersions returns the version IDs of a function, useful to check what a test left behind.
+func (s *Server) FunctionVersions(functionID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.functions[functionID]
+	if !ok {
+		return nil
+	}
+
+	versionIDs := make([]string, 0, len(f.versions))
+	for _, v := range f.sortedVersions() {
+		versionIDs = append(versionIDs, v.info.VersionID)
+	}
+	return versionIDs
+}
+
+// FunctionSecrets returns the secrets of a function version, useful to check a secret was rotated.
+func (s *Server) FunctionSecrets(functionID string, versionID string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := s.version(functionID, versionID)
+	if v == nil {
+		return nil
+	}
+
+	secrets := make(map[string]interface{}, len(v.secrets))
+	for name, value := range v.secrets {
+		secrets[name] = value
+	}
+	return secrets
+}
+
+// orgPathPattern matches the org and optional team prefix of every NVCF path.
+var orgPathPattern = regexp.MustCompile(`^/v2/orgs/([^/]+)(?:/teams/[^/]+)?(/nvcf/.*)$`)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := uuid.New().String()
+	w.Header().Set("Nvcf-Reqid", requestID)
+
+	if r.Header.Get("Authorization") != "Bearer "+s.config.APIKey {
+		// NVCF replies to unauthenticated requests without a body.
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	match := orgPathPattern.FindStringSubmatch(r.URL.Path)
+	if match == nil {
+		writeProblemDetails(w, r, http.StatusNotFound, "Not Found", fmt.Sprintf("No route for %s", r.URL.Path))
+		return
+	}
+	if match[1] != s.config.Org {
+		writeProblemDetails(w, r, http.StatusForbidden, "Forbidden", fmt.Sprintf("Not authorized for org %s", match[1]))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nvcfPath := match[2]
+	if fault := s.matchFault(r.Method, nvcfPath); fault != nil {
+		for k, values := range fault.Header {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+		if fault.ProblemDetails {
+			writeProblemDetails(w, r, fault.StatusCode, http.StatusText(fault.StatusCode), "Injected fault")
+		} else {
+			writeRequestStatus(w, requestID, fault.StatusCode, "INJECTED_FAULT", "Injected fault")
+		}
+		return
+	}
+
+	segments := strings.Split(strings.TrimPrefix(nvcfPath, "/nvcf/"), "/")
+	ctx := &requestContext{w: w, r: r, requestID: requestID}
+
+	switch {
+	case matchSegments(segments, "functions"):
+		switch r.Method {
+		case http.MethodGet:
+			s.listFunctions(ctx)
+		case http.MethodPost:
+			s.createFunction(ctx, "")
+		default:
+			ctx.methodNotAllowed()
+		}
+	case matchSegments(segments, "functions", "*", "versions"):
+		switch r.Method {
+		case http.MethodGet:
+			s.listFunctionVersions(ctx, segments[1])
+		case http.MethodPost:
+			s.createFunction(ctx, segments[1])
+		default:
+			ctx.methodNotAllowed()
+		}
+	case matchSegments(segments, "functions", "*", "versions", "*"):
+		switch r.Method {
+		case http.MethodGet:
+			s.getFunctionVersion(ctx, segments[1], segments[3])
+		case http.MethodDelete:
+			s.deleteFunctionVersion(ctx, segments[1], segments[3])
+		default:
+			ctx.methodNotAllowed()
+		}
+	case matchSegments(segments, "metadata", "functions", "*", "versions", "*"):
+		if r.Method != http.MethodPut {
+			ctx.methodNotAllowed()
+			return
+		}
+		s.updateMetadata(ctx, segments[2], segments[4])
+	case matchSegments(segments, "secrets", "functions", "*", "versions", "*"):
+		if r.Method != http.MethodPut {
+			ctx.methodNotAllowed()
+			return
+		}
+		s.updateSecrets(ctx, segments[2], segments[4])
+	case matchSegments(segments, "deployments", "functions", "*", "versions", "*"):
+		switch r.Method {
+		case http.MethodGet:
+			s.getDeployment(ctx, segments[2], segments[4])
+		case http.MethodPost:
+			s.createDeployment(ctx, segments[2], segments[4])
+		case http.MethodPut:
+			s.updateDeployment(ctx, segments[2], segments[4])
+		case http.MethodDelete:
+			s.deleteDeployment(ctx, segments[2], segments[4])
+		default:
+			ctx.methodNotAllowed()
+		}
+	case matchSegments(segments, "authorizations", "functions", "*"):
+		switch r.Method {
+		case http.MethodGet:
+			s.getAuthorization(ctx, segments[2], "")
+		case http.MethodPost:
+			s.authorize(ctx, segments[2], "")
+		case http.MethodDelete:
+			s.unauthorize(ctx, segments[2], "")
+		default:
+			ctx.methodNotAllowed()
+		}
+	case matchSegments(segments, "authorizations", "functions", "*", "*"):
+		if r.Method != http.MethodPatch || (segments[3] != "add" && segments[3] != "remove") {
+			ctx.methodNotAllowed()
+			return
+		}
+		s.updateAuthorizedParty(ctx, segments[2], "", segments[3])
+	case matchSegments(segments, "authorizations", "functions", "*", "versions", "*", "*"):
+		if r.Method != http.MethodPatch || (segments[5] != "add" && segments[5] != "remove") {
+			ctx.methodNotAllowed()
+			return
+		}
+		s.updateAuthorizedParty(ctx, segments[2], segments[4], segments[5])
+	case matchSegments(segments, "authorizations", "functions", "*", "versions", "*"):
+		switch r.Method {
+		case http.MethodGet:
+			s.getAuthorization(ctx, segments[2], segments[4])
+		case http.MethodPost:
+			s.authorize(ctx, segments[2], segments[4])
+		case http.MethodDelete:
+			s.unauthorize(ctx, segments[2], segments[4])
+		default:
+			ctx.methodNotAllowed()
+		}
+	default:
+		writeProblemDetails(w, r, http.StatusNotFound, "Not Found", fmt.Sprintf("No route for %s", r.URL.Path))
+	}
+}
+
+// matchFault returns the first matching fault and consumes one of its failures.
+func (s *Server) matchFault(method string, nvcfPath string) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+		if fault.Path != "" && !strings.HasSuffix(nvcfPath, fault.Path) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// matchSegments matches path segments against a pattern, "*" matching any non-empty segment.
+func matchSegments(segments []string, pattern ...string) bool {
+	if len(segments) != len(pattern) {
+		return false
+	}
+
+	for i, p := range pattern {
+		if segments[i] == "" || (p != "*" && p != segments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+type requestContext struct {
+	w         http.ResponseWriter
+	r         *http.Request
+	requestID string
+}
+
+func (c *requestContext) decode(v any) bool {
+	if err := json.NewDecoder(c.r.Body).Decode(v); err != nil {
+		c.badRequest(fmt.Sprintf("Malformed request body: %s", err))
+		return false
+	}
+	return true
+}
+
+func (c *requestContext) writeJSON(statusCode int, v any) {
+	c.w.Header().Set("Content-Type", "application/json")
+	c.w.WriteHeader(statusCode)
+	_ = json.NewEncoder(c.w).Encode(v)
+}
+
+func (c *requestContext) badRequest(detail string) {
+	writeProblemDetails(c.w, c.r, http.StatusBadRequest, "Bad Request", "Validation failure: "+detail)
+}
+
+func (c *requestContext) conflict(detail string) {
+	writeProblemDetails(c.w, c.r, http.StatusConflict, "Conflict", detail)
+}
+
+func (c *requestContext) notFound(description string) {
+	writeRequestStatus(c.w, c.requestID, http.StatusNotFound, "NOT_FOUND", description)
+}
+
+func (c *requestContext) methodNotAllowed() {
+	writeProblemDetails(c.w, c.r, http.StatusMethodNotAllowed, "Method Not Allowed", fmt.Sprintf("%s is not supported on %s", c.r.Method, c.r.URL.Path))
+}
+
+// writeRequestStatus replies with the requestStatus error format.
+func writeRequestStatus(w http.ResponseWriter, requestID string, statusCode int, code string, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(utils.ErrorResponse{
+		RequestStatus: utils.RequestStatusModel{
+			StatusCode:        code,
+			StatusDescription: description,
+			RequestID:         requestID,
+		},
+	})
+}
+
+// writeProblemDetails replies with the RFC 7807 problem details error format.
+func writeProblemDetails(w http.ResponseWriter, r *http.Request, statusCode int, title string, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(utils.ErrorResponse{
+		Type:     "urn:nvcf-worker-service:problem-details:" + strings.ToLower(strings.ReplaceAll(title, " ", "-")),
+		Title:    title,
+		Status:   statusCode,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	})
+}
@@ -0,0 +1,448 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package fakenvcf
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func newTestClient(t *testing.T, config Config) (*Server, *utils.NVCFClient) {
+	t.Helper()
+
+	server := NewServer(config)
+	t.Cleanup(server.Close)
+
+	client := &utils.NGCClient{
+		NgcEndpoint: server.URL(),
+		NgcApiKey:   server.Config().APIKey,
+		NgcOrg:      server.Config().Org,
+		NgcTeam:     "fakenvcf-team",
+		HttpClient:  http.DefaultClient,
+		DeploymentPollPolicy: utils.DeploymentPollPolicy{
+			Interval: 10 * time.Millisecond,
+		},
+	}
+	return server, client.NVCFClient()
+}
+
+func testCreateFunctionRequest() utils.CreateNvidiaCloudFunctionRequest {
+	return utils.CreateNvidiaCloudFunctionRequest{
+		FunctionName:   "fakenvcf-function",
+		ContainerImage: "nvcr.io/fakenvcf-org/echo:0.1",
+		InferenceUrl:   "/echo",
+		InferencePort:  8000,
+		HealthUri:      "/health",
+		Tags:           []string{"tag1"},
+		Secrets: []utils.NvidiaCloudFunctionSecret{
+			{Name: "secret", Value: "value"},
+		},
+	}
+}
+
+func testDeploymentSpecifications(minInstances int, maxInstances int) []utils.NvidiaCloudFunctionDeploymentSpecification {
+	return []utils.NvidiaCloudFunctionDeploymentSpecification{
+		{
+			Gpu:                   "L40",
+			Backend:               "fakenvcf-backend",
+			InstanceType:          "FAKENVCF.GPU.L40_1x",
+			MinInstances:          minInstances,
+			MaxInstances:          maxInstances,
+			MaxRequestConcurrency: 1,
+		},
+	}
+}
+
+func TestServer_FunctionLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, client := newTestClient(t, Config{})
+
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", testCreateFunctionRequest())
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	function := created.Function
+	assert.Equal(t, DefaultNcaID, function.NcaID)
+	assert.Equal(t, StatusInactive, function.Status)
+	assert.Equal(t, "CUSTOM", function.APIBodyFormat)
+	assert.Equal(t, "DEFAULT", function.FunctionType)
+	assert.Equal(t, []string{"secret"}, function.Secrets)
+	assert.Equal(t, &utils.NvidiaCloudFunctionHealth{Protocol: "HTTP", URI: "/health", Port: 8000, Timeout: "PT10S", ExpectedStatusCode: 200}, function.Health)
+
+	second, err := client.CreateNvidiaCloudFunction(ctx, function.ID, testCreateFunctionRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, function.ID, second.Function.ID)
+
+	versions, err := client.ListNvidiaCloudFunctionVersions(ctx, function.ID)
+	assert.NoError(t, err)
+	assert.Len(t, versions.Functions, 2)
+
+	functions, err := client.ListNvidiaCloudFunctions(ctx, []string{"authorized"})
+	assert.NoError(t, err)
+	assert.Empty(t, functions.Functions)
+
+	_, err = client.UpdateNvidiaCloudFunctionMetadata(ctx, function.ID, function.VersionID, utils.UpdateNvidiaCloudFunctionMetadataRequest{Tags: []string{"tag2"}})
+	assert.NoError(t, err)
+
+	got, err := client.GetNvidiaCloudFunctionVersion(ctx, function.ID, function.VersionID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tag2"}, got.Function.Tags)
+
+	assert.NoError(t, client.DeleteNvidiaCloudFunctionVersion(ctx, function.ID, function.VersionID))
+	assert.NoError(t, client.DeleteNvidiaCloudFunctionVersion(ctx, function.ID, second.Function.VersionID))
+
+	_, err = client.GetNvidiaCloudFunctionVersion(ctx, function.ID, function.VersionID)
+	assert.True(t, utils.IsNotFound(err))
+}
+
+func TestServer_DeploymentLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server, client := newTestClient(t, Config{DeploymentDuration: 50 * time.Millisecond})
+
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", testCreateFunctionRequest())
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	functionID, versionID := created.Function.ID, created.Function.VersionID
+
+	_, err = client.CreateNvidiaCloudFunctionDeployment(ctx, functionID, versionID, utils.CreateNvidiaCloudFunctionDeploymentRequest{
+		DeploymentSpecifications: testDeploymentSpecifications(2, 1),
+	})
+	assert.ErrorContains(t, err, "Validation failure")
+
+	deployment, err := client.CreateNvidiaCloudFunctionDeployment(ctx, functionID, versionID, utils.CreateNvidiaCloudFunctionDeploymentRequest{
+		DeploymentSpecifications: testDeploymentSpecifications(1, 1),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusDeploying, deployment.Deployment.FunctionStatus)
+
+	_, err = client.CreateNvidiaCloudFunctionDeployment(ctx, functionID, versionID, utils.CreateNvidiaCloudFunctionDeploymentRequest{
+		DeploymentSpecifications: testDeploymentSpecifications(1, 1),
+	})
+	assert.True(t, utils.IsConflict(err))
+
+	got, err := client.GetNvidiaCloudFunctionVersion(ctx, functionID, versionID)
+	assert.NoError(t, err)
+	if assert.Len(t, got.Function.ActiveInstances, 1) {
+		assert.Equal(t, "PENDING", got.Function.ActiveInstances[0].InstanceStatus)
+	}
+
+	assert.NoError(t, client.WaitingDeploymentCompleted(ctx, functionID, versionID))
+
+	// Scaling an ACTIVE deployment does not redeploy it.
+	updated, err := client.UpdateNvidiaCloudFunctionDeployment(ctx, functionID, versionID, utils.UpdateNvidiaCloudFunctionDeploymentRequest{
+		DeploymentSpecifications: testDeploymentSpecifications(1, 3),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusActive, updated.Deployment.FunctionStatus)
+
+	// Its instances are only scaled once the scaling completes.
+	_, err = client.UpdateNvidiaCloudFunctionDeployment(ctx, functionID, versionID, utils.UpdateNvidiaCloudFunctionDeploymentRequest{
+		DeploymentSpecifications: testDeploymentSpecifications(2, 3),
+	})
+	assert.NoError(t, err)
+	got, err = client.GetNvidiaCloudFunctionVersion(ctx, functionID, versionID)
+	assert.NoError(t, err)
+	assert.Len(t, got.Function.ActiveInstances, 1)
+	assert.NoError(t, client.WaitingDeploymentScaled(ctx, functionID, versionID, testDeploymentSpecifications(2, 3)))
+	got, err = client.GetNvidiaCloudFunctionVersion(ctx, functionID, versionID)
+	assert.NoError(t, err)
+	assert.Len(t, got.Function.ActiveInstances, 2)
+
+	assert.NoError(t, server.SetDeploymentStatus(functionID, versionID, StatusError))
+	var deploymentErr *utils.DeploymentError
+	assert.ErrorAs(t, client.WaitingDeploymentCompleted(ctx, functionID, versionID), &deploymentErr)
+
+	deleted, err := client.DeleteNvidiaCloudFunctionDeployment(ctx, functionID, versionID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusInactive, deleted.Function.Status)
+
+	read, err := client.ReadNvidiaCloudFunctionDeployment(ctx, functionID, versionID)
+	assert.NoError(t, err)
+	assert.Empty(t, read.Deployment.FunctionStatus)
+}
+
+func TestServer_Authorizations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, client := newTestClient(t, Config{})
+
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", testCreateFunctionRequest())
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	functionID, versionID := created.Function.ID, created.Function.VersionID
+
+	parties := []utils.AuthorizedParty{{NcaID: "nca-1"}, {NcaID: "nca-2"}}
+	authorized, err := client.AuthorizeAccountsToInvokeFunction(ctx, functionID, versionID, utils.AuthorizeAccountsToInvokeFunctionRequest{AuthorizedParties: parties})
+	assert.NoError(t, err)
+	assert.Equal(t, parties, authorized.Function.AuthorizedParties)
+
+	got, err := client.GetFunctionAuthorization(ctx, functionID, versionID)
+	assert.NoError(t, err)
+	assert.Equal(t, parties, got.Function.AuthorizedParties)
+
+	assert.NoError(t, client.UnAuthorizeAllExtraAccountsToInvokeFunction(ctx, functionID, versionID))
+
+	got, err = client.GetFunctionAuthorization(ctx, functionID, versionID)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Function.AuthorizedParties)
+}
+
+func TestServer_AuthorizedParties(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, client := newTestClient(t, Config{})
+
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", testCreateFunctionRequest())
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	functionID, versionID := created.Function.ID, created.Function.VersionID
+
+	type authorizations struct {
+		versionID string
+		add       func(party utils.AuthorizedParty) (*utils.AuthorizeAccountsToInvokeFunctionResponse, error)
+		remove    func(party utils.AuthorizedParty) (*utils.AuthorizeAccountsToInvokeFunctionResponse, error)
+		get       func() (*utils.AuthorizeAccountsToInvokeFunctionResponse, error)
+	}
+
+	// Function level parties are kept apart from the version ones.
+	for _, a := range []authorizations{
+		{
+			versionID: versionID,
+			add: func(party utils.AuthorizedParty) (*utils.AuthorizeAccountsToInvokeFunctionResponse, error) {
+				return client.AddAuthorizedParty(ctx, functionID, versionID, party)
+			},
+			remove: func(party utils.AuthorizedParty) (*utils.AuthorizeAccountsToInvokeFunctionResponse, error) {
+				return client.RemoveAuthorizedParty(ctx, functionID, versionID, party)
+			},
+			get: func() (*utils.AuthorizeAccountsToInvokeFunctionResponse, error) {
+				return client.GetFunctionAuthorization(ctx, functionID, versionID)
+			},
+		},
+		{
+			add: func(party utils.AuthorizedParty) (*utils.AuthorizeAccountsToInvokeFunctionResponse, error) {
+				return client.AddAllFunctionVersionsAuthorizedParty(ctx, functionID, party)
+			},
+			remove: func(party utils.AuthorizedParty) (*utils.AuthorizeAccountsToInvokeFunctionResponse, error) {
+				return client.RemoveAllFunctionVersionsAuthorizedParty(ctx, functionID, party)
+			},
+			get: func() (*utils.AuthorizeAccountsToInvokeFunctionResponse, error) {
+				return client.GetAllFunctionVersionsAuthorization(ctx, functionID)
+			},
+		},
+	} {
+		added, err := a.add(utils.AuthorizedParty{NcaID: "nca-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, []utils.AuthorizedParty{{NcaID: "nca-1"}}, added.Function.AuthorizedParties)
+
+		_, err = a.add(utils.AuthorizedParty{NcaID: "nca-1"})
+		assert.ErrorContains(t, err, "already authorized")
+
+		_, err = a.add(utils.AuthorizedParty{NcaID: "nca-1", ClientId: "client-1"})
+		assert.NoError(t, err)
+
+		removed, err := a.remove(utils.AuthorizedParty{NcaID: "nca-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, []utils.AuthorizedParty{{NcaID: "nca-1", ClientId: "client-1"}}, removed.Function.AuthorizedParties)
+
+		_, err = a.remove(utils.AuthorizedParty{NcaID: "nca-2"})
+		assert.ErrorContains(t, err, "not authorized")
+
+		got, err := a.get()
+		assert.NoError(t, err)
+		assert.Equal(t, a.versionID, got.Function.VersionID)
+		assert.Equal(t, []utils.AuthorizedParty{{NcaID: "nca-1", ClientId: "client-1"}}, got.Function.AuthorizedParties)
+	}
+
+	_, err = client.GetAllFunctionVersionsAuthorization(ctx, "missing")
+	assert.True(t, utils.IsNotFound(err), "GetAllFunctionVersionsAuthorization() error = %v", err)
+}
+
+func TestServer_FunctionAuthorization(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, client := newTestClient(t, Config{})
+
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", testCreateFunctionRequest())
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	functionID, versionID := created.Function.ID, created.Function.VersionID
+
+	parties := []utils.AuthorizedParty{{NcaID: "nca-1"}}
+	authorized, err := client.AuthorizeAccountsToInvokeAllFunctionVersions(ctx, functionID, utils.AuthorizeAccountsToInvokeFunctionRequest{AuthorizedParties: parties})
+	assert.NoError(t, err)
+	assert.Equal(t, parties, authorized.Function.AuthorizedParties)
+
+	// Function level parties survive the versions being replaced.
+	replaced, err := client.CreateNvidiaCloudFunction(ctx, functionID, testCreateFunctionRequest())
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	assert.NoError(t, client.DeleteNvidiaCloudFunctionVersion(ctx, functionID, versionID))
+
+	got, err := client.GetAllFunctionVersionsAuthorization(ctx, functionID)
+	assert.NoError(t, err)
+	assert.Equal(t, parties, got.Function.AuthorizedParties)
+
+	got, err = client.GetFunctionAuthorization(ctx, functionID, replaced.Function.VersionID)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Function.AuthorizedParties)
+
+	assert.NoError(t, client.UnAuthorizeAllExtraAccountsToInvokeAllFunctionVersions(ctx, functionID))
+
+	got, err = client.GetAllFunctionVersionsAuthorization(ctx, functionID)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Function.AuthorizedParties)
+}
+
+func TestServer_Secrets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server, client := newTestClient(t, Config{})
+
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", testCreateFunctionRequest())
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	functionID, versionID := created.Function.ID, created.Function.VersionID
+	assert.Equal(t, map[string]interface{}{"secret": "value"}, server.FunctionSecrets(functionID, versionID))
+
+	err = client.UpdateNvidiaCloudFunctionSecrets(ctx, functionID, versionID, utils.UpdateNvidiaCloudFunctionSecretsRequest{
+		Secrets: []utils.NvidiaCloudFunctionSecret{{Name: "other"}},
+	})
+	assert.ErrorContains(t, err, "secrets must have a name and a value")
+
+	err = client.UpdateNvidiaCloudFunctionSecrets(ctx, functionID, versionID, utils.UpdateNvidiaCloudFunctionSecretsRequest{
+		Secrets: []utils.NvidiaCloudFunctionSecret{{Name: "other", Value: "rotated"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"other": "rotated"}, server.FunctionSecrets(functionID, versionID))
+
+	got, err := client.GetNvidiaCloudFunctionVersion(ctx, functionID, versionID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"other"}, got.Function.Secrets)
+}
+
+func TestServer_Errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server, client := newTestClient(t, Config{})
+
+	tests := []struct {
+		name      string
+		fault     *Fault
+		client    func() *utils.NVCFClient
+		wantCheck func(error) bool
+		wantError string
+	}{
+		{
+			name:      "Unauthenticated",
+			client:    func() *utils.NVCFClient { c := *client; c.NgcApiKey = "wrong-key"; return &c },
+			wantCheck: utils.IsUnauthorized,
+			wantError: "not authenticated",
+		},
+		{
+			name:      "WrongOrg",
+			client:    func() *utils.NVCFClient { c := *client; c.NgcOrg = "other-org"; return &c },
+			wantCheck: utils.IsUnauthorized,
+			wantError: "Not authorized for org other-org",
+		},
+		{
+			name:      "NotFound",
+			wantCheck: utils.IsNotFound,
+			wantError: "Function 'missing-function' version 'missing-version' not found",
+		},
+		{
+			name:      "RequestStatusFault",
+			fault:     &Fault{Method: http.MethodGet, Path: "/versions/missing-version", StatusCode: http.StatusInternalServerError, Times: 1},
+			wantCheck: func(err error) bool { return !utils.IsNotFound(err) },
+			wantError: "Injected fault",
+		},
+		{
+			name:      "ProblemDetailsFault",
+			fault:     &Fault{Method: http.MethodGet, Path: "/versions/missing-version", StatusCode: http.StatusConflict, Times: 1, ProblemDetails: true},
+			wantCheck: utils.IsConflict,
+			wantError: "Injected fault",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := client
+			if tt.client != nil {
+				c = tt.client()
+			}
+			if tt.fault != nil {
+				server.InjectFault(*tt.fault)
+			}
+
+			_, err := c.GetNvidiaCloudFunctionVersion(ctx, "missing-function", "missing-version")
+			assert.ErrorContains(t, err, tt.wantError)
+			assert.True(t, tt.wantCheck(err))
+		})
+	}
+}
+
+func TestServer_FaultRetried(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server, client := newTestClient(t, Config{})
+	client.RetryPolicy = utils.RetryPolicy{
+		MaxRetries:           2,
+		RetryWaitMin:         time.Millisecond,
+		RetryWaitMax:         time.Millisecond,
+		RetryableStatusCodes: utils.DefaultRetryableStatusCodes(),
+	}
+
+	server.InjectFault(Fault{
+		Method:     http.MethodPost,
+		Path:       "/nvcf/functions",
+		StatusCode: http.StatusTooManyRequests,
+		Times:      2,
+		Header:     http.Header{"Retry-After": []string{"0"}},
+	})
+
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", testCreateFunctionRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{created.Function.VersionID}, server.FunctionVersions(created.Function.ID))
+
+	// A create which may have reached the server is not replayed.
+	server.InjectFault(Fault{
+		Method:     http.MethodPost,
+		Path:       "/nvcf/functions/" + created.Function.ID + "/versions",
+		StatusCode: http.StatusServiceUnavailable,
+		Times:      1,
+	})
+
+	_, err = client.CreateNvidiaCloudFunction(ctx, created.Function.ID, testCreateFunctionRequest())
+	assert.Error(t, err)
+	assert.Equal(t, []string{created.Function.VersionID}, server.FunctionVersions(created.Function.ID))
+}
@@ -0,0 +1,407 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NvidiaCloudFunctionAuthorizationResource{}
+var _ resource.ResourceWithImportState = &NvidiaCloudFunctionAuthorizationResource{}
+var _ resource.ResourceWithValidateConfig = &NvidiaCloudFunctionAuthorizationResource{}
+var _ resource.ResourceWithModifyPlan = &NvidiaCloudFunctionAuthorizationResource{}
+
+func NewNvidiaCloudFunctionAuthorizationResource() resource.Resource {
+	return &NvidiaCloudFunctionAuthorizationResource{}
+}
+
+// NvidiaCloudFunctionAuthorizationResource defines the resource implementation.
+type NvidiaCloudFunctionAuthorizationResource struct {
+	client *utils.NVCFClient
+}
+
+// NvidiaCloudFunctionAuthorizationResourceModel describes the resource data model.
+type NvidiaCloudFunctionAuthorizationResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	FunctionID        types.String `tfsdk:"function_id"`
+	VersionID         types.String `tfsdk:"version_id"`
+	Scope             types.String `tfsdk:"scope"`
+	AuthorizedParties types.Set    `tfsdk:"authorized_parties"`
+}
+
+type NvidiaCloudFunctionAuthorizationPartyModel struct {
+	NcaID    types.String `tfsdk:"nca_id"`
+	ClientID types.String `tfsdk:"client_id"`
+}
+
+func (r *NvidiaCloudFunctionAuthorizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_function_authorization"
+}
+
+func authorizationPartiesSchema() schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: "Parties authorized to invoke the function. Parties added or removed are authorized or unauthorized one by one, " +
+			"the other parties keep their access. Parties authorized outside of this resource are left untouched.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"nca_id": schema.StringAttribute{
+					MarkdownDescription: "NVIDIA Cloud Account authorized to invoke the function",
+					Required:            true,
+				},
+				"client_id": schema.StringAttribute{
+					MarkdownDescription: "Client ID of the NVIDIA Cloud Account authorized to invoke the function, any client of the account when unset",
+					Optional:            true,
+				},
+			},
+		},
+		Required: true,
+	}
+}
+
+func (r *NvidiaCloudFunctionAuthorizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Nvidia Cloud Function Authorization Resource. Authorizes parties to invoke a function version, " +
+			"or the function itself, whatever its version, with the \"function\" `scope`, independently from the `authorized_parties` of `ngc_cloud_function`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Read-only identifier with format `function_id,version_id`, or `function_id` for the function",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"function_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Function ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Function Version ID. The parties are authorized to invoke the function itself, whatever its version, when unset",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Scope of the authorization. With \"version\", the parties are authorized to invoke the function version `version_id`, which is required. " +
+					"With \"function\", the parties are authorized to invoke the function, whatever its version, and keep their access while its versions are replaced, `version_id` must be unset. " +
+					"Defaults to \"version\" when `version_id` is set and to \"function\" otherwise",
+			},
+			"authorized_parties": authorizationPartiesSchema(),
+		},
+	}
+}
+
+func (r *NvidiaCloudFunctionAuthorizationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NvidiaCloudFunctionAuthorizationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Scope.IsNull() || data.Scope.IsUnknown() {
+		return
+	}
+
+	switch data.Scope.ValueString() {
+	case authorizationScopeFunction:
+		if !data.VersionID.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("version_id"),
+				"Conflicting version_id Configuration",
+				"The \"function\" scope authorizes the parties to invoke every version of the function, version_id must be unset.",
+			)
+		}
+	case authorizationScopeVersion:
+		if data.VersionID.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("version_id"),
+				"Missing version_id Configuration",
+				"The \"version\" scope authorizes the parties to invoke a single version of the function, version_id must be set.",
+			)
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("scope"),
+			"Invalid scope Configuration",
+			fmt.Sprintf("scope must be one of %q, got %q.", authorizationScopes, data.Scope.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan sets the scope from version_id when it is not configured.
+func (r *NvidiaCloudFunctionAuthorizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan NvidiaCloudFunctionAuthorizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || !plan.Scope.IsUnknown() || plan.VersionID.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("scope"), authorizationScope(plan.VersionID))...)
+}
+
+func (r *NvidiaCloudFunctionAuthorizationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = ngcClient.NVCFClient()
+}
+
+func (r *NvidiaCloudFunctionAuthorizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NvidiaCloudFunctionAuthorizationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := authorizationParties(ctx, data.AuthorizedParties, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parties, err := updateAuthorizedParties(ctx, r.client, data.authorizationTarget(), planned, nil)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to authorize Cloud Function parties",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	r.updateNvidiaCloudFunctionAuthorizationResourceModel(ctx, &resp.Diagnostics, &data, managedAuthorizedParties(parties, planned))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NvidiaCloudFunctionAuthorizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NvidiaCloudFunctionAuthorizationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getFunctionAuthorizationResponse, err := data.authorizationTarget().get(ctx, r.client)
+
+	if err != nil {
+		if utils.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Cloud Function %s no longer exists, removing from state", data.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Failed to get Cloud Function authorization",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	// The parties are null right after import, when every authorized party is managed.
+	var managed []utils.AuthorizedParty
+	if !data.AuthorizedParties.IsNull() {
+		managed = authorizationParties(ctx, data.AuthorizedParties, &resp.Diagnostics)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.updateNvidiaCloudFunctionAuthorizationResourceModel(ctx, &resp.Diagnostics, &data, managedAuthorizedParties(getFunctionAuthorizationResponse.Function.AuthorizedParties, managed))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NvidiaCloudFunctionAuthorizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state NvidiaCloudFunctionAuthorizationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := authorizationParties(ctx, plan.AuthorizedParties, &resp.Diagnostics)
+	managed := authorizationParties(ctx, state.AuthorizedParties, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parties, err := updateAuthorizedParties(ctx, r.client, plan.authorizationTarget(), planned, managed)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to update Cloud Function authorized parties",
+			utils.ErrorDetail(err),
+		)
+		// Some parties may have been updated already, the next refresh reads them back.
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	r.updateNvidiaCloudFunctionAuthorizationResourceModel(ctx, &resp.Diagnostics, &plan, managedAuthorizedParties(parties, planned))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NvidiaCloudFunctionAuthorizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NvidiaCloudFunctionAuthorizationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := authorizationParties(ctx, data.AuthorizedParties, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateAuthorizedParties(ctx, r.client, data.authorizationTarget(), []utils.AuthorizedParty{}, managed)
+	if err != nil && !utils.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to unauthorize Cloud Function parties",
+			utils.ErrorDetail(err),
+		)
+	}
+}
+
+func (r *NvidiaCloudFunctionAuthorizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+
+	if len(idParts) > 2 || slices.Contains(idParts, "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: function_id,version_id or function_id. Got: %q", req.ID),
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("function_id"), idParts[0])...)
+	if len(idParts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version_id"), idParts[1])...)
+	}
+}
+
+func (r *NvidiaCloudFunctionAuthorizationResource) updateNvidiaCloudFunctionAuthorizationResourceModel(
+	ctx context.Context, diag *diag.Diagnostics,
+	data *NvidiaCloudFunctionAuthorizationResourceModel,
+	authorizedParties []utils.AuthorizedParty,
+) {
+	data.Id = data.FunctionID
+	if !data.VersionID.IsNull() {
+		data.Id = types.StringValue(fmt.Sprintf("%s,%s", data.FunctionID.ValueString(), data.VersionID.ValueString()))
+	}
+	data.Scope = authorizationScope(data.VersionID)
+
+	parties := make([]NvidiaCloudFunctionAuthorizationPartyModel, 0, len(authorizedParties))
+	for _, v := range authorizedParties {
+		party := NvidiaCloudFunctionAuthorizationPartyModel{
+			NcaID:    types.StringValue(v.NcaID),
+			ClientID: types.StringNull(),
+		}
+		if v.ClientId != "" {
+			party.ClientID = types.StringValue(v.ClientId)
+		}
+		parties = append(parties, party)
+	}
+
+	partiesSetType, partiesSetTypeDiag := types.SetValueFrom(ctx, authorizationPartiesSchema().NestedObject.Type(), parties)
+	diag.Append(partiesSetTypeDiag...)
+	data.AuthorizedParties = partiesSetType
+}
+
+// authorizationTarget returns what the parties are authorized to invoke, the function itself when version_id is null.
+func (m NvidiaCloudFunctionAuthorizationResourceModel) authorizationTarget() authorizationTarget {
+	if m.VersionID.IsNull() {
+		return functionAuthorizationTarget(m.FunctionID.ValueString())
+	}
+	return functionVersionAuthorizationTarget(m.FunctionID.ValueString(), m.VersionID.ValueString())
+}
+
+// authorizationScope returns the scope of the authorization of the version, or of the function when versionID is null.
+func authorizationScope(versionID types.String) types.String {
+	if versionID.IsNull() {
+		return types.StringValue(authorizationScopeFunction)
+	}
+	return types.StringValue(authorizationScopeVersion)
+}
+
+// authorizationParties converts the authorized_parties of ngc_cloud_function_authorization to NVCF parties.
+func authorizationParties(ctx context.Context, partiesRawData basetypes.SetValue, diag *diag.Diagnostics) []utils.AuthorizedParty {
+	parties := make([]NvidiaCloudFunctionAuthorizationPartyModel, 0, len(partiesRawData.Elements()))
+	diag.Append(partiesRawData.ElementsAs(ctx, &parties, false)...)
+
+	authorizedParties := make([]utils.AuthorizedParty, 0, len(parties))
+	for _, v := range parties {
+		authorizedParties = append(authorizedParties, utils.AuthorizedParty{
+			NcaID:    v.NcaID.ValueString(),
+			ClientId: v.ClientID.ValueString(),
+		})
+	}
+	return authorizedParties
+}
@@ -0,0 +1,183 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build !unittest
+// +build !unittest
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/testutils"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func testCheckAuthorizedParties(functionID string, versionID string, authorized []string, unauthorized []string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		resp, err := testutils.TestNVCFClient.GetFunctionAuthorization(testutils.Ctx, functionID, versionID)
+		if err != nil {
+			return err
+		}
+
+		parties := resp.Function.AuthorizedParties
+		for _, v := range authorized {
+			if !slices.Contains(parties, utils.AuthorizedParty{NcaID: v}) {
+				return fmt.Errorf("expected %s to be authorized, got %v", v, parties)
+			}
+		}
+		for _, v := range unauthorized {
+			if slices.Contains(parties, utils.AuthorizedParty{NcaID: v}) {
+				return fmt.Errorf("expected %s to be unauthorized, got %v", v, parties)
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccCloudFunctionAuthorizationResource_ContainerBasedFunction(t *testing.T) {
+	var testVersionAuthorizationFullPath = "ngc_cloud_function_authorization.version"
+	var testTeamAuthorizationFullPath = "ngc_cloud_function_authorization.team"
+	var testFunctionAuthorizationFullPath = "ngc_cloud_function_authorization.function"
+
+	functionInfo := testutils.CreateContainerFunction(t)
+	defer testutils.DeleteFunction(t, functionInfo.Function.ID, functionInfo.Function.VersionID)
+
+	functionID := functionInfo.Function.ID
+	versionID := functionInfo.Function.VersionID
+
+	versionAuthorization := func(versionParties string) string {
+		return fmt.Sprintf(`
+			resource "ngc_cloud_function_authorization" "version" {
+				function_id        = "%s"
+				version_id         = "%s"
+				authorized_parties = [%s]
+			}
+			`,
+			functionID,
+			versionID,
+			versionParties,
+		)
+	}
+
+	authorizationConfig := func(versionAuthorization string, functionScope string) string {
+		return versionAuthorization + fmt.Sprintf(`
+			resource "ngc_cloud_function_authorization" "team" {
+				function_id        = "%[1]s"
+				version_id         = "%[2]s"
+				authorized_parties = [
+					{
+						nca_id = "%[4]s"
+					}
+				]
+			}
+
+			resource "ngc_cloud_function_authorization" "function" {
+				function_id        = "%[1]s"
+				%[5]s
+				authorized_parties = [
+					{
+						nca_id = "%[3]s"
+					}
+				]
+			}
+			`,
+			functionID,
+			versionID,
+			testutils.TestAuthorizedParty1,
+			testutils.TestAuthorizedParty2,
+			functionScope,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify scope validation
+			{
+				Config:      authorizationConfig(versionAuthorization(""), `scope = "version"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Missing version_id Configuration"),
+			},
+			{
+				Config:      authorizationConfig(versionAuthorization(""), `scope = "all"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid scope Configuration"),
+			},
+			// Verify Authorization Creation
+			{
+				Config: authorizationConfig(versionAuthorization(fmt.Sprintf(`{ nca_id = "%s" }`, testutils.TestAuthorizedParty1)), `scope = "function"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testVersionAuthorizationFullPath, "id", fmt.Sprintf("%s,%s", functionID, versionID)),
+					resource.TestCheckResourceAttr(testVersionAuthorizationFullPath, "authorized_parties.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(testVersionAuthorizationFullPath, "authorized_parties.*", map[string]string{
+						"nca_id": testutils.TestAuthorizedParty1,
+					}),
+					resource.TestCheckResourceAttr(testVersionAuthorizationFullPath, "scope", "version"),
+					resource.TestCheckResourceAttr(testTeamAuthorizationFullPath, "authorized_parties.#", "1"),
+					resource.TestCheckResourceAttr(testFunctionAuthorizationFullPath, "id", functionID),
+					resource.TestCheckResourceAttr(testFunctionAuthorizationFullPath, "scope", "function"),
+					resource.TestCheckNoResourceAttr(testFunctionAuthorizationFullPath, "version_id"),
+					resource.TestCheckResourceAttr(testFunctionAuthorizationFullPath, "authorized_parties.#", "1"),
+					testCheckAuthorizedParties(functionID, versionID, []string{testutils.TestAuthorizedParty1, testutils.TestAuthorizedParty2}, nil),
+					testCheckAuthorizedParties(functionID, "", []string{testutils.TestAuthorizedParty1}, []string{testutils.TestAuthorizedParty2}),
+				),
+			},
+			// Verify the parties of the other resources are ignored
+			{
+				Config: authorizationConfig(versionAuthorization(fmt.Sprintf(`{ nca_id = "%s" }`, testutils.TestAuthorizedParty1)), `scope = "function"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Verify removing a party keeps the other parties authorized
+			{
+				Config: authorizationConfig(versionAuthorization(""), `scope = "function"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(testVersionAuthorizationFullPath, plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction(testTeamAuthorizationFullPath, plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction(testFunctionAuthorizationFullPath, plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testVersionAuthorizationFullPath, "authorized_parties.#", "0"),
+					resource.TestCheckResourceAttr(testTeamAuthorizationFullPath, "authorized_parties.#", "1"),
+					testCheckAuthorizedParties(functionID, versionID, []string{testutils.TestAuthorizedParty2}, []string{testutils.TestAuthorizedParty1}),
+					testCheckAuthorizedParties(functionID, "", []string{testutils.TestAuthorizedParty1}, nil),
+				),
+			},
+			// Verify Authorization Import, the version authorization sharing its ID with the team one is removed first
+			{
+				Config: authorizationConfig("", `scope = "function"`),
+				Check:  testCheckAuthorizedParties(functionID, versionID, []string{testutils.TestAuthorizedParty2}, nil),
+			},
+			{
+				ResourceName:      testTeamAuthorizationFullPath,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      testFunctionAuthorizationFullPath,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,152 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+const (
+	// authorizationScopeFunction authorizes the parties to invoke the function, whatever its version.
+	authorizationScopeFunction = "function"
+	// authorizationScopeVersion authorizes the parties to invoke a single version of the function.
+	authorizationScopeVersion = "version"
+)
+
+var authorizationScopes = []string{authorizationScopeFunction, authorizationScopeVersion}
+
+// authorizationTarget is what the parties are authorized to invoke, a version of the function, or the function
+// itself, whatever its version, when allVersions is set.
+type authorizationTarget struct {
+	functionID  string
+	versionID   string
+	allVersions bool
+}
+
+func functionAuthorizationTarget(functionID string) authorizationTarget {
+	return authorizationTarget{functionID: functionID, allVersions: true}
+}
+
+func functionVersionAuthorizationTarget(functionID string, versionID string) authorizationTarget {
+	return authorizationTarget{functionID: functionID, versionID: versionID}
+}
+
+// scopedAuthorizationTarget returns what the parties of the version are authorized to invoke with the given scope.
+func scopedAuthorizationTarget(functionID string, scope types.String, versionID string) authorizationTarget {
+	if scope.ValueString() == authorizationScopeFunction {
+		return functionAuthorizationTarget(functionID)
+	}
+	return functionVersionAuthorizationTarget(functionID, versionID)
+}
+
+func (t authorizationTarget) get(ctx context.Context, client *utils.NVCFClient) (*utils.AuthorizeAccountsToInvokeFunctionResponse, error) {
+	if t.allVersions {
+		return client.GetAllFunctionVersionsAuthorization(ctx, t.functionID)
+	}
+	return client.GetFunctionAuthorization(ctx, t.functionID, t.versionID)
+}
+
+func (t authorizationTarget) add(ctx context.Context, client *utils.NVCFClient, party utils.AuthorizedParty) (*utils.AuthorizeAccountsToInvokeFunctionResponse, error) {
+	if t.allVersions {
+		return client.AddAllFunctionVersionsAuthorizedParty(ctx, t.functionID, party)
+	}
+	return client.AddAuthorizedParty(ctx, t.functionID, t.versionID, party)
+}
+
+func (t authorizationTarget) remove(ctx context.Context, client *utils.NVCFClient, party utils.AuthorizedParty) (*utils.AuthorizeAccountsToInvokeFunctionResponse, error) {
+	if t.allVersions {
+		return client.RemoveAllFunctionVersionsAuthorizedParty(ctx, t.functionID, party)
+	}
+	return client.RemoveAuthorizedParty(ctx, t.functionID, t.versionID, party)
+}
+
+// authorizedPartiesDiff returns the planned parties that are not authorized yet, and the managed parties that are
+// still authorized but no longer planned. Parties authorized by others are neither added nor removed.
+func authorizedPartiesDiff(planned []utils.AuthorizedParty, managed []utils.AuthorizedParty, current []utils.AuthorizedParty) (add []utils.AuthorizedParty, remove []utils.AuthorizedParty) {
+	for _, v := range planned {
+		if !slices.Contains(current, v) && !slices.Contains(add, v) {
+			add = append(add, v)
+		}
+	}
+	for _, v := range managed {
+		if slices.Contains(current, v) && !slices.Contains(planned, v) && !slices.Contains(remove, v) {
+			remove = append(remove, v)
+		}
+	}
+
+	compareParties := func(a utils.AuthorizedParty, b utils.AuthorizedParty) int {
+		return strings.Compare(a.NcaID+","+a.ClientId, b.NcaID+","+b.ClientId)
+	}
+	slices.SortFunc(add, compareParties)
+	slices.SortFunc(remove, compareParties)
+	return add, remove
+}
+
+// managedAuthorizedParties returns the authorized parties that are managed, or every authorized party when managed is nil.
+func managedAuthorizedParties(current []utils.AuthorizedParty, managed []utils.AuthorizedParty) []utils.AuthorizedParty {
+	if managed == nil {
+		return current
+	}
+
+	parties := make([]utils.AuthorizedParty, 0, len(managed))
+	for _, v := range current {
+		if slices.Contains(managed, v) {
+			parties = append(parties, v)
+		}
+	}
+	return parties
+}
+
+// updateAuthorizedParties authorizes the planned parties and unauthorizes the managed parties no longer planned, one by one,
+// so the other parties never lose their access. It returns every party authorized once done.
+func updateAuthorizedParties(
+	ctx context.Context,
+	client *utils.NVCFClient,
+	target authorizationTarget,
+	planned []utils.AuthorizedParty,
+	managed []utils.AuthorizedParty,
+) ([]utils.AuthorizedParty, error) {
+	getFunctionAuthorizationResponse, err := target.get(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	current := getFunctionAuthorizationResponse.Function.AuthorizedParties
+	add, remove := authorizedPartiesDiff(planned, managed, current)
+
+	for _, v := range add {
+		tflog.Info(ctx, fmt.Sprintf("authorizing %s to invoke Cloud Function %s", v.NcaID, target.functionID))
+
+		addAuthorizedPartyResponse, err := target.add(ctx, client, v)
+		if err != nil {
+			return nil, err
+		}
+		current = addAuthorizedPartyResponse.Function.AuthorizedParties
+	}
+
+	for _, v := range remove {
+		tflog.Info(ctx, fmt.Sprintf("unauthorizing %s to invoke Cloud Function %s", v.NcaID, target.functionID))
+
+		removeAuthorizedPartyResponse, err := target.remove(ctx, client, v)
+		if err != nil {
+			return nil, err
+		}
+		current = removeAuthorizedPartyResponse.Function.AuthorizedParties
+	}
+	return current, nil
+}
@@ -0,0 +1,392 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/fakenvcf"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestAuthorizedPartiesDiff(t *testing.T) {
+	t.Parallel()
+
+	partyA := utils.AuthorizedParty{NcaID: "A"}
+	partyB := utils.AuthorizedParty{NcaID: "B"}
+	partyC := utils.AuthorizedParty{NcaID: "C"}
+	partyAClient := utils.AuthorizedParty{NcaID: "A", ClientId: "client"}
+
+	tests := []struct {
+		name       string
+		planned    []utils.AuthorizedParty
+		managed    []utils.AuthorizedParty
+		current    []utils.AuthorizedParty
+		wantAdd    []utils.AuthorizedParty
+		wantRemove []utils.AuthorizedParty
+	}{
+		{
+			name:    "Create",
+			planned: []utils.AuthorizedParty{partyB, partyA},
+			wantAdd: []utils.AuthorizedParty{partyA, partyB},
+		},
+		{
+			name:    "AlreadyAuthorized",
+			planned: []utils.AuthorizedParty{partyA, partyB},
+			current: []utils.AuthorizedParty{partyA},
+			wantAdd: []utils.AuthorizedParty{partyB},
+		},
+		{
+			name:       "RemoveManagedOnly",
+			planned:    []utils.AuthorizedParty{},
+			managed:    []utils.AuthorizedParty{partyA},
+			current:    []utils.AuthorizedParty{partyA, partyC},
+			wantRemove: []utils.AuthorizedParty{partyA},
+		},
+		{
+			name:    "RemovedOutside",
+			planned: []utils.AuthorizedParty{partyB},
+			managed: []utils.AuthorizedParty{partyA, partyB},
+			current: []utils.AuthorizedParty{partyB},
+		},
+		{
+			name:       "ClientID",
+			planned:    []utils.AuthorizedParty{partyAClient},
+			managed:    []utils.AuthorizedParty{partyA},
+			current:    []utils.AuthorizedParty{partyA},
+			wantAdd:    []utils.AuthorizedParty{partyAClient},
+			wantRemove: []utils.AuthorizedParty{partyA},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			add, remove := authorizedPartiesDiff(tt.planned, tt.managed, tt.current)
+			assert.Equal(t, tt.wantAdd, add)
+			assert.Equal(t, tt.wantRemove, remove)
+		})
+	}
+}
+
+func TestManagedAuthorizedParties(t *testing.T) {
+	t.Parallel()
+
+	partyA := utils.AuthorizedParty{NcaID: "A"}
+	partyB := utils.AuthorizedParty{NcaID: "B"}
+
+	tests := []struct {
+		name    string
+		current []utils.AuthorizedParty
+		managed []utils.AuthorizedParty
+		want    []utils.AuthorizedParty
+	}{
+		{
+			name:    "Import",
+			current: []utils.AuthorizedParty{partyA, partyB},
+			want:    []utils.AuthorizedParty{partyA, partyB},
+		},
+		{
+			name:    "Managed",
+			current: []utils.AuthorizedParty{partyA, partyB},
+			managed: []utils.AuthorizedParty{partyB},
+			want:    []utils.AuthorizedParty{partyB},
+		},
+		{
+			name:    "RemovedOutside",
+			current: []utils.AuthorizedParty{partyA},
+			managed: []utils.AuthorizedParty{partyB},
+			want:    []utils.AuthorizedParty{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, managedAuthorizedParties(tt.current, tt.managed))
+		})
+	}
+}
+
+func TestScopedAuthorizationTarget(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, authorizationTarget{functionID: "function", versionID: "version"}, scopedAuthorizationTarget("function", types.StringValue(authorizationScopeVersion), "version"))
+	assert.Equal(t, authorizationTarget{functionID: "function", versionID: "version"}, scopedAuthorizationTarget("function", types.StringNull(), "version"))
+	assert.Equal(t, authorizationTarget{functionID: "function", allVersions: true}, scopedAuthorizationTarget("function", types.StringValue(authorizationScopeFunction), "version"))
+}
+
+func TestNvidiaCloudFunctionResource_DeleteFunctionScope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakenvcf.NewServer(fakenvcf.Config{})
+	defer server.Close()
+
+	client := &utils.NVCFClient{
+		NgcEndpoint: server.URL(),
+		NgcApiKey:   server.Config().APIKey,
+		NgcOrg:      server.Config().Org,
+		HttpClient:  http.DefaultClient,
+	}
+	r := &NvidiaCloudFunctionResource{client: client}
+
+	createRequest := utils.CreateNvidiaCloudFunctionRequest{
+		FunctionName:   "function-scope",
+		ContainerImage: "nvcr.io/mock-org/echo:0.1",
+		InferenceUrl:   "/echo",
+		InferencePort:  8000,
+		HealthUri:      "/health",
+	}
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", createRequest)
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	functionID := created.Function.ID
+	other, err := client.CreateNvidiaCloudFunction(ctx, functionID, createRequest)
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+
+	parties := []utils.AuthorizedParty{{NcaID: "nca-1"}}
+	_, err = client.AuthorizeAccountsToInvokeAllFunctionVersions(ctx, functionID, utils.AuthorizeAccountsToInvokeFunctionRequest{AuthorizedParties: parties})
+	if err != nil {
+		t.Fatalf("AuthorizeAccountsToInvokeAllFunctionVersions() error = %v", err)
+	}
+
+	deleteVersion := func(versionID string) {
+		t.Helper()
+
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		authorizedParties, diags := types.SetValueFrom(ctx, authorizedPartiesSchema().NestedObject.Type(), []NvidiaCloudFunctionResourceAuthorizedPartyModel{
+			{NcaID: types.StringValue("nca-1")},
+		})
+		diags.Append(state.SetAttribute(ctx, path.Root("id"), functionID)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("version_id"), versionID)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("authorized_parties_scope"), authorizationScopeFunction)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("authorized_parties"), authorizedParties)...)
+		if diags.HasError() {
+			t.Fatalf("state diagnostics = %v", diags)
+		}
+
+		resp := resource.DeleteResponse{State: state}
+		r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+		assert.False(t, resp.Diagnostics.HasError(), "Delete() diagnostics = %v", resp.Diagnostics)
+	}
+
+	// The parties authorized at the function level keep their access while another version remains.
+	deleteVersion(created.Function.VersionID)
+
+	got, err := client.GetAllFunctionVersionsAuthorization(ctx, functionID)
+	assert.NoError(t, err)
+	assert.Equal(t, parties, got.Function.AuthorizedParties)
+	assert.Equal(t, []string{other.Function.VersionID}, server.FunctionVersions(functionID))
+
+	deleteVersion(other.Function.VersionID)
+
+	assert.Empty(t, server.FunctionVersions(functionID))
+}
+
+func TestUpdateFunctionAuthorizedPartiesScope_FunctionToVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakenvcf.NewServer(fakenvcf.Config{})
+	defer server.Close()
+
+	client := &utils.NVCFClient{
+		NgcEndpoint: server.URL(),
+		NgcApiKey:   server.Config().APIKey,
+		NgcOrg:      server.Config().Org,
+		HttpClient:  http.DefaultClient,
+	}
+
+	createRequest := utils.CreateNvidiaCloudFunctionRequest{
+		FunctionName:   "scope-move",
+		ContainerImage: "nvcr.io/mock-org/echo:0.1",
+		InferenceUrl:   "/echo",
+		InferencePort:  8000,
+		HealthUri:      "/health",
+	}
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", createRequest)
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	functionID, versionID := created.Function.ID, created.Function.VersionID
+	other, err := client.CreateNvidiaCloudFunction(ctx, functionID, createRequest)
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+
+	parties := []utils.AuthorizedParty{{NcaID: "nca-1"}}
+	_, err = client.AuthorizeAccountsToInvokeAllFunctionVersions(ctx, functionID, utils.AuthorizeAccountsToInvokeFunctionRequest{AuthorizedParties: parties})
+	if err != nil {
+		t.Fatalf("AuthorizeAccountsToInvokeAllFunctionVersions() error = %v", err)
+	}
+
+	authorizedParties, diags := types.SetValueFrom(ctx, authorizedPartiesSchema().NestedObject.Type(), []NvidiaCloudFunctionResourceAuthorizedPartyModel{
+		{NcaID: types.StringValue("nca-1")},
+	})
+	if diags.HasError() {
+		t.Fatalf("SetValueFrom() diagnostics = %v", diags)
+	}
+	state := NvidiaCloudFunctionResourceModel{
+		Id:                     types.StringValue(functionID),
+		VersionID:              types.StringValue(versionID),
+		AuthorizedParties:      authorizedParties,
+		AuthorizedPartiesScope: types.StringValue(authorizationScopeFunction),
+	}
+	plan := state
+	plan.AuthorizedPartiesScope = types.StringValue(authorizationScopeVersion)
+
+	moveScope := func() {
+		t.Helper()
+
+		updateFunctionAuthorizedPartiesScope(ctx, functionID, versionID, plan, &state, &diags, *client)
+		assert.False(t, diags.HasError(), "updateFunctionAuthorizedPartiesScope() diagnostics = %v", diags)
+
+		got, err := client.GetFunctionAuthorization(ctx, functionID, versionID)
+		assert.NoError(t, err)
+		assert.Equal(t, parties, got.Function.AuthorizedParties)
+	}
+
+	// The callers of the other version keep invoking it with the parties authorized at the function level.
+	moveScope()
+
+	got, err := client.GetAllFunctionVersionsAuthorization(ctx, functionID)
+	assert.NoError(t, err)
+	assert.Equal(t, parties, got.Function.AuthorizedParties)
+
+	assert.NoError(t, client.DeleteNvidiaCloudFunctionVersion(ctx, functionID, other.Function.VersionID))
+
+	// Once the version of the resource is the last one, the parties only invoke it.
+	moveScope()
+
+	got, err = client.GetAllFunctionVersionsAuthorization(ctx, functionID)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Function.AuthorizedParties)
+}
+
+func TestNvidiaCloudFunctionResource_FailedRolloutFunctionScope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	// The new version is not deployed before the update times out.
+	server := fakenvcf.NewServer(fakenvcf.Config{DeploymentDuration: time.Hour})
+	defer server.Close()
+
+	client := &utils.NVCFClient{
+		NgcEndpoint: server.URL(),
+		NgcApiKey:   server.Config().APIKey,
+		NgcOrg:      server.Config().Org,
+		HttpClient:  http.DefaultClient,
+		DeploymentPollPolicy: utils.DeploymentPollPolicy{
+			Interval: 10 * time.Millisecond,
+		},
+	}
+	r := &NvidiaCloudFunctionResource{client: client}
+
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", utils.CreateNvidiaCloudFunctionRequest{
+		FunctionName:   "failed-rollout",
+		ContainerImage: "nvcr.io/mock-org/echo:0.1",
+		InferenceUrl:   "/echo",
+		InferencePort:  8000,
+	})
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	functionID, versionID := created.Function.ID, created.Function.VersionID
+
+	parties := []utils.AuthorizedParty{{NcaID: "nca-1"}}
+	_, err = client.AuthorizeAccountsToInvokeAllFunctionVersions(ctx, functionID, utils.AuthorizeAccountsToInvokeFunctionRequest{AuthorizedParties: parties})
+	if err != nil {
+		t.Fatalf("AuthorizeAccountsToInvokeAllFunctionVersions() error = %v", err)
+	}
+
+	authorizedParties := func(ncaID string) types.Set {
+		t.Helper()
+
+		set, diags := types.SetValueFrom(ctx, authorizedPartiesSchema().NestedObject.Type(), []NvidiaCloudFunctionResourceAuthorizedPartyModel{
+			{NcaID: types.StringValue(ncaID)},
+		})
+		if diags.HasError() {
+			t.Fatalf("SetValueFrom() diagnostics = %v", diags)
+		}
+		return set
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	var diags diag.Diagnostics
+	deploymentSpecifications := deploymentSpecificationsListValue(ctx, []utils.NvidiaCloudFunctionDeploymentSpecification{
+		testDeploymentSpecification("fakenvcf-backend", "L40", 1),
+	}, &diags)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), functionID)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("version_id"), versionID)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("function_name"), "failed-rollout")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("container_image"), "nvcr.io/mock-org/echo:0.1")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("inference_url"), "/echo")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("inference_port"), 8000)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("rollout_strategy"), rolloutStrategyBlueGreen)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("keep_failed_resource"), false)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("deployment_specifications"), deploymentSpecifications)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("authorized_parties_scope"), authorizationScopeFunction)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("authorized_parties"), authorizedParties("nca-1"))...)
+
+	// The rollout replaces the party authorized at the function level.
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+	diags.Append(plan.SetAttribute(ctx, path.Root("container_image"), "nvcr.io/mock-org/echo:0.2")...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("authorized_parties"), authorizedParties("nca-2"))...)
+	if diags.HasError() {
+		t.Fatalf("state diagnostics = %v", diags)
+	}
+
+	updateCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+
+	resp := resource.UpdateResponse{State: state}
+	r.Update(updateCtx, resource.UpdateRequest{
+		State:  state,
+		Plan:   plan,
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+	}, &resp)
+	assert.True(t, resp.Diagnostics.HasError(), "the rollout did not fail")
+
+	// The callers of the old version keep invoking it with the parties authorized at the function level.
+	got, err := client.GetAllFunctionVersionsAuthorization(ctx, functionID)
+	assert.NoError(t, err)
+	assert.Equal(t, parties, got.Function.AuthorizedParties)
+	assert.Equal(t, []string{versionID}, server.FunctionVersions(functionID))
+}
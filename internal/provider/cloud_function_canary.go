@@ -0,0 +1,263 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+const (
+	defaultCanaryPause = 5 * time.Minute
+	// canaryRollbackTimeout bounds the rollback, which runs even once the update timed out.
+	canaryRollbackTimeout = 30 * time.Minute
+)
+
+func canarySchema() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Canary rollout of a new function version, used with `rollout_strategy = \"canary\"`. " +
+			"The old and new versions stay deployed together while the capacity of `deployment_specifications` or `deployments` is shifted to the new version.",
+		Attributes: map[string]schema.Attribute{
+			"steps": schema.ListAttribute{
+				MarkdownDescription: "Increasing percentages of the capacity given to the new version at each step, such as `[10, 50]`. " +
+					"The old version keeps the rest of its capacity. After the last step, the new version gets the whole capacity and the old version is deleted.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"pause": schema.StringAttribute{
+				MarkdownDescription: "How long to wait after each step before checking the health of the new version, such as \"5m\". Default is \"5m\"",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// scaleInstances returns the share of an instance count, rounded up.
+func scaleInstances(instances int, percent int64) int {
+	return int((int64(instances)*percent + 99) / 100)
+}
+
+// canaryDeploymentSpecifications scales the deployment specifications to a percentage of their capacity,
+// keeping at least one max instance for the deployment to stay valid.
+func canaryDeploymentSpecifications(specifications []utils.NvidiaCloudFunctionDeploymentSpecification, percent int64) []utils.NvidiaCloudFunctionDeploymentSpecification {
+	scaled := make([]utils.NvidiaCloudFunctionDeploymentSpecification, 0, len(specifications))
+	for _, v := range specifications {
+		v.MaxInstances = max(1, scaleInstances(v.MaxInstances, percent))
+		v.MinInstances = min(v.MaxInstances, scaleInstances(v.MinInstances, percent))
+		scaled = append(scaled, v)
+	}
+	return scaled
+}
+
+// canaryPlan reads the steps and the pause of the canary block.
+func canaryPlan(ctx context.Context, plan NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) ([]int64, time.Duration) {
+	var canary NvidiaCloudFunctionResourceCanaryModel
+	diag.Append(plan.Canary.As(ctx, &canary, basetypes.ObjectAsOptions{})...)
+
+	steps := make([]int64, 0, len(canary.Steps.Elements()))
+	diag.Append(canary.Steps.ElementsAs(ctx, &steps, false)...)
+
+	pause := defaultCanaryPause
+	if !canary.Pause.IsNull() {
+		var err error
+		if pause, err = time.ParseDuration(canary.Pause.ValueString()); err != nil {
+			diag.AddError("Invalid canary Configuration", err.Error())
+		}
+	}
+	return steps, pause
+}
+
+// canaryRollout deploys a new version of the function next to the old one, then shifts the capacity
+// to it step by step, checking its health after each pause. Once the last step is healthy, the new
+// version gets the whole capacity and the old version is deleted. When a step fails, the old version
+// gets its capacity back and the new version is deleted unless keep_failed_resource is set.
+func (r *NvidiaCloudFunctionResource) canaryRollout(ctx context.Context, plan NvidiaCloudFunctionResourceModel, state NvidiaCloudFunctionResourceModel, resp *resource.UpdateResponse) {
+	steps, pause := canaryPlan(ctx, plan, &resp.Diagnostics)
+	newSpecifications := plan.deploymentSpecifications(ctx, &resp.Diagnostics)
+	oldSpecifications := state.deploymentSpecifications(ctx, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if oldSpecifications == nil {
+		tflog.Info(ctx, "the old version has no deployment to shift the capacity from, rolling out the new version blue/green")
+		r.blueGreenRollout(ctx, plan, state, resp)
+		return
+	}
+
+	// The response state is the plan by default, keep the old version in it until the rollout completes.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	function, authorizedAccounts := r.createRolloutVersion(ctx, plan, state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rollout := &canaryDeployments{
+		client:            r.client,
+		functionID:        function.ID,
+		newVersionID:      function.VersionID,
+		oldVersionID:      state.VersionID.ValueString(),
+		newSpecifications: newSpecifications,
+		oldSpecifications: oldSpecifications,
+	}
+
+	for i, percent := range steps {
+		if percent >= 100 {
+			break
+		}
+
+		err := rollout.step(ctx, percent, pause)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Canary rollout failed at step %d (%d%%)", i+1, percent),
+				utils.ErrorDetail(err),
+			)
+			r.rollbackCanary(ctx, rollout, plan.KeepFailedResource.ValueBool(), &resp.Diagnostics)
+			return
+		}
+	}
+
+	deployment, err := rollout.deployNewVersion(ctx, newSpecifications)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Canary rollout failed at the last step (100%)",
+			utils.ErrorDetail(err),
+		)
+		r.rollbackCanary(ctx, rollout, plan.KeepFailedResource.ValueBool(), &resp.Diagnostics)
+		return
+	}
+
+	r.completeRollout(ctx, plan, state, function, deployment, authorizedAccounts, resp)
+}
+
+// rollbackCanary gives the old version its capacity back, then deletes the new version. The update
+// context may be done after a timeout, the rollback has its own.
+func (r *NvidiaCloudFunctionResource) rollbackCanary(ctx context.Context, rollout *canaryDeployments, keepFailedResource bool, diag *diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), canaryRollbackTimeout)
+	defer cancel()
+
+	tflog.Warn(ctx, fmt.Sprintf("rolling back the canary rollout of Cloud Function version %s", rollout.newVersionID))
+
+	if rollout.oldScaled {
+		err := rollout.scaleOldVersion(ctx, rollout.oldSpecifications)
+		if err != nil {
+			diag.AddError(
+				fmt.Sprintf("Failed to roll back Cloud Function Deployment %s", rollout.oldVersionID),
+				utils.ErrorDetail(err),
+			)
+		}
+	}
+
+	r.deleteFailedDeploymentVersion(ctx, keepFailedResource, rollout.functionID, rollout.newVersionID, diag)
+}
+
+// canaryDeployments tracks the deployments of the old and new versions during a canary rollout.
+type canaryDeployments struct {
+	client            *utils.NVCFClient
+	functionID        string
+	newVersionID      string
+	oldVersionID      string
+	newSpecifications []utils.NvidiaCloudFunctionDeploymentSpecification
+	oldSpecifications []utils.NvidiaCloudFunctionDeploymentSpecification
+	// newDeployed is set once the new version is deployed, oldScaled once the old version is scaled down.
+	newDeployed bool
+	oldScaled   bool
+}
+
+// step gives a percentage of the capacity to the new version, then the rest to the old version,
+// and checks the health of the new version after the pause.
+func (c *canaryDeployments) step(ctx context.Context, percent int64, pause time.Duration) error {
+	tflog.Info(ctx, fmt.Sprintf("shifting %d%% of the capacity to Cloud Function version %s", percent, c.newVersionID))
+
+	_, err := c.deployNewVersion(ctx, canaryDeploymentSpecifications(c.newSpecifications, percent))
+	if err != nil {
+		return err
+	}
+
+	// The old version may be scaled down even when waiting for it fails.
+	c.oldScaled = true
+	err = c.scaleOldVersion(ctx, canaryDeploymentSpecifications(c.oldSpecifications, 100-percent))
+	if err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(pause)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	readNvidiaCloudFunctionDeploymentResponse, err := c.client.ReadNvidiaCloudFunctionDeployment(ctx, c.functionID, c.newVersionID)
+	if err != nil {
+		return err
+	}
+
+	if status := readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus; status != "ACTIVE" {
+		return fmt.Errorf("function version %s is %s after %s", c.newVersionID, status, pause)
+	}
+	return nil
+}
+
+// deployNewVersion creates or scales the deployment of the new version and waits for its instances to match.
+func (c *canaryDeployments) deployNewVersion(ctx context.Context, specifications []utils.NvidiaCloudFunctionDeploymentSpecification) (*utils.NvidiaCloudFunctionDeployment, error) {
+	var deployment utils.NvidiaCloudFunctionDeployment
+
+	if c.newDeployed {
+		updateNvidiaCloudFunctionDeploymentResponse, err := c.client.UpdateNvidiaCloudFunctionDeployment(ctx, c.functionID, c.newVersionID, utils.UpdateNvidiaCloudFunctionDeploymentRequest{
+			DeploymentSpecifications: specifications,
+		})
+		if err != nil {
+			return nil, err
+		}
+		deployment = updateNvidiaCloudFunctionDeploymentResponse.Deployment
+	} else {
+		createNvidiaCloudFunctionDeploymentResponse, err := c.client.CreateNvidiaCloudFunctionDeployment(ctx, c.functionID, c.newVersionID, utils.CreateNvidiaCloudFunctionDeploymentRequest{
+			DeploymentSpecifications: specifications,
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.newDeployed = true
+		deployment = createNvidiaCloudFunctionDeploymentResponse.Deployment
+	}
+
+	err := c.client.WaitingDeploymentScaled(ctx, c.functionID, c.newVersionID, specifications)
+	if err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+// scaleOldVersion scales the deployment of the old version and waits for its instances to match.
+func (c *canaryDeployments) scaleOldVersion(ctx context.Context, specifications []utils.NvidiaCloudFunctionDeploymentSpecification) error {
+	_, err := c.client.UpdateNvidiaCloudFunctionDeployment(ctx, c.functionID, c.oldVersionID, utils.UpdateNvidiaCloudFunctionDeploymentRequest{
+		DeploymentSpecifications: specifications,
+	})
+	if err != nil {
+		return err
+	}
+	return c.client.WaitingDeploymentScaled(ctx, c.functionID, c.oldVersionID, specifications)
+}
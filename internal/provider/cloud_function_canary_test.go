@@ -0,0 +1,142 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestCanaryDeploymentSpecifications(t *testing.T) {
+	t.Parallel()
+
+	specification := func(minInstances int, maxInstances int) utils.NvidiaCloudFunctionDeploymentSpecification {
+		return utils.NvidiaCloudFunctionDeploymentSpecification{
+			Gpu:                   "L40",
+			Backend:               "backend",
+			InstanceType:          "GPU.L40_1x",
+			MinInstances:          minInstances,
+			MaxInstances:          maxInstances,
+			MaxRequestConcurrency: 1,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		specifications []utils.NvidiaCloudFunctionDeploymentSpecification
+		percent        int64
+		want           []utils.NvidiaCloudFunctionDeploymentSpecification
+	}{
+		{
+			name:           "Half",
+			specifications: []utils.NvidiaCloudFunctionDeploymentSpecification{specification(4, 10)},
+			percent:        50,
+			want:           []utils.NvidiaCloudFunctionDeploymentSpecification{specification(2, 5)},
+		},
+		{
+			name:           "RoundedUp",
+			specifications: []utils.NvidiaCloudFunctionDeploymentSpecification{specification(1, 3)},
+			percent:        10,
+			want:           []utils.NvidiaCloudFunctionDeploymentSpecification{specification(1, 1)},
+		},
+		{
+			name:           "KeepsOneMaxInstance",
+			specifications: []utils.NvidiaCloudFunctionDeploymentSpecification{specification(0, 2)},
+			percent:        0,
+			want:           []utils.NvidiaCloudFunctionDeploymentSpecification{specification(0, 1)},
+		},
+		{
+			name:           "Whole",
+			specifications: []utils.NvidiaCloudFunctionDeploymentSpecification{specification(1, 2), specification(3, 6)},
+			percent:        100,
+			want:           []utils.NvidiaCloudFunctionDeploymentSpecification{specification(1, 2), specification(3, 6)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, canaryDeploymentSpecifications(tt.specifications, tt.percent))
+		})
+	}
+}
+
+func TestValidateCanaryConfig(t *testing.T) {
+	t.Parallel()
+
+	steps := func(percents ...int64) types.List {
+		elements := make([]attr.Value, 0, len(percents))
+		for _, v := range percents {
+			elements = append(elements, types.Int64Value(v))
+		}
+		return types.ListValueMust(types.Int64Type, elements)
+	}
+
+	tests := []struct {
+		name      string
+		canary    NvidiaCloudFunctionResourceCanaryModel
+		wantError string
+	}{
+		{
+			name:   "Valid",
+			canary: NvidiaCloudFunctionResourceCanaryModel{Steps: steps(10, 50, 100), Pause: types.StringValue("5m")},
+		},
+		{
+			name:   "DefaultPause",
+			canary: NvidiaCloudFunctionResourceCanaryModel{Steps: steps(25), Pause: types.StringNull()},
+		},
+		{
+			name:      "NoStep",
+			canary:    NvidiaCloudFunctionResourceCanaryModel{Steps: types.ListNull(types.Int64Type), Pause: types.StringNull()},
+			wantError: "steps must list at least one percentage",
+		},
+		{
+			name:      "DecreasingSteps",
+			canary:    NvidiaCloudFunctionResourceCanaryModel{Steps: steps(50, 10), Pause: types.StringNull()},
+			wantError: "steps must be increasing percentages between 1 and 100.",
+		},
+		{
+			name:      "StepAbove100",
+			canary:    NvidiaCloudFunctionResourceCanaryModel{Steps: steps(150), Pause: types.StringNull()},
+			wantError: "steps must be increasing percentages between 1 and 100.",
+		},
+		{
+			name:      "InvalidPause",
+			canary:    NvidiaCloudFunctionResourceCanaryModel{Steps: steps(50), Pause: types.StringValue("five minutes")},
+			wantError: "pause must be a non-negative duration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			validateCanaryConfig(context.Background(), tt.canary, &diags)
+
+			if tt.wantError == "" {
+				assert.False(t, diags.HasError(), "validateCanaryConfig() diagnostics = %v", diags)
+				return
+			}
+			if assert.True(t, diags.HasError()) {
+				assert.Contains(t, diags.Errors()[0].Detail(), tt.wantError)
+			}
+		})
+	}
+}
@@ -12,12 +12,14 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
 )
@@ -40,6 +42,7 @@ type NvidiaCloudFunctionDataSourceModel struct {
 	VersionID                types.String                            `tfsdk:"version_id"`
 	NcaId                    types.String                            `tfsdk:"nca_id"`
 	FunctionName             types.String                            `tfsdk:"function_name"`
+	VersionSelector          types.String                            `tfsdk:"version_selector"`
 	HelmChart                types.String                            `tfsdk:"helm_chart"`
 	HelmChartServiceName     types.String                            `tfsdk:"helm_chart_service_name"`
 	InferencePort            types.Int64                             `tfsdk:"inference_port"`
@@ -116,40 +119,21 @@ func (d *NvidiaCloudFunctionDataSource) updateNvidiaCloudFunctionDataSourceModel
 	}
 
 	if functionDeployment.DeploymentSpecifications != nil {
-		deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0)
-
-		for _, v := range functionDeployment.DeploymentSpecifications {
-			deploymentSpecification := NvidiaCloudFunctionResourceDeploymentSpecificationModel{
-				Backend:               types.StringValue(v.Backend),
-				InstanceType:          types.StringValue(v.InstanceType),
-				GpuType:               types.StringValue(v.Gpu),
-				MaxInstances:          types.Int64Value(int64(v.MaxInstances)),
-				MinInstances:          types.Int64Value(int64(v.MinInstances)),
-				MaxRequestConcurrency: types.Int64Value(int64(v.MaxRequestConcurrency)),
-			}
-
-			if v.Configuration != nil {
-				configuration, _ := json.Marshal(v.Configuration)
-				deploymentSpecification.Configuration = types.StringValue(string(configuration))
-			}
-
-			deploymentSpecifications = append(deploymentSpecifications, deploymentSpecification)
-		}
-		deploymentSpecificationsListType, deploymentSpecificationsListTypeDiag := types.ListValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), deploymentSpecifications)
-		diag.Append(deploymentSpecificationsListTypeDiag...)
-		data.DeploymentSpecifications = deploymentSpecificationsListType
+		data.DeploymentSpecifications = deploymentSpecificationsListValue(ctx, functionDeployment.DeploymentSpecifications, diag)
 	}
 
 	tags, tagsSetFromDiag := types.SetValueFrom(ctx, types.StringType, functionInfo.Tags)
 	diag.Append(tagsSetFromDiag...)
 	data.Tags = tags
 
-	data.Health = &NvidiaCloudFunctionResourceHealthModel{
-		Protocol:           types.StringValue(functionInfo.Health.Protocol),
-		Uri:                types.StringValue(functionInfo.Health.URI),
-		Port:               types.Int64Value(int64(functionInfo.Health.Port)),
-		Timeout:            types.StringValue(functionInfo.Health.Timeout),
-		ExpectedStatusCode: types.Int64Value(int64(functionInfo.Health.ExpectedStatusCode)),
+	if functionInfo.Health != nil {
+		data.Health = &NvidiaCloudFunctionResourceHealthModel{
+			Protocol:           types.StringValue(functionInfo.Health.Protocol),
+			Uri:                types.StringValue(functionInfo.Health.URI),
+			Port:               types.Int64Value(int64(functionInfo.Health.Port)),
+			Timeout:            types.StringValue(functionInfo.Health.Timeout),
+			ExpectedStatusCode: types.Int64Value(int64(functionInfo.Health.ExpectedStatusCode)),
+		}
 	}
 
 	if functionInfo.ContainerEnvironment != nil {
@@ -218,24 +202,31 @@ func (d *NvidiaCloudFunctionDataSource) Metadata(ctx context.Context, req dataso
 func (d *NvidiaCloudFunctionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Nvidia Cloud Function Data Source",
+		MarkdownDescription: "Nvidia Cloud Function Data Source. The function version is looked up by `function_id`/`version_id`, or selected among the versions of the function by `version_selector` when `version_id` is omitted.",
 
 		Attributes: map[string]schema.Attribute{
 			"function_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Function ID",
+				MarkdownDescription: "Function ID. Either `function_id` or `function_name` is required",
+				Optional:            true,
+				Computed:            true,
 			},
 			"version_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Function Version ID",
+				MarkdownDescription: "Function Version ID. When omitted, the version is picked by `version_selector`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"version_selector": schema.StringAttribute{
+				MarkdownDescription: "How to pick the function version when `version_id` is omitted, versions being ordered by creation time: \"latest\", \"latest_active\" or \"oldest\". Default is \"latest\"",
+				Optional:            true,
 			},
 			"nca_id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "NCA ID",
 			},
 			"function_name": schema.StringAttribute{
-				MarkdownDescription: "Function name",
+				MarkdownDescription: "Function name. Either `function_id` or `function_name` is required",
 				Optional:            true,
+				Computed:            true,
 			},
 			"helm_chart": schema.StringAttribute{
 				MarkdownDescription: "Helm chart registry uri",
@@ -317,6 +308,62 @@ func (d *NvidiaCloudFunctionDataSource) Configure(ctx context.Context, req datas
 	d.client = ngcClient.NVCFClient()
 }
 
+func (d *NvidiaCloudFunctionDataSource) validateVersionLookup(diag *diag.Diagnostics, data *NvidiaCloudFunctionDataSourceModel) {
+	if data.FunctionID.ValueString() == "" && data.FunctionName.ValueString() == "" {
+		diag.AddError(
+			"Missing Function Identifier",
+			"Either `function_id` or `function_name` must be set.",
+		)
+	}
+
+	if data.VersionID.ValueString() != "" && data.VersionSelector.ValueString() != "" {
+		diag.AddAttributeError(
+			path.Root("version_selector"),
+			"Conflicting Version Lookup",
+			"`version_selector` can't be used together with `version_id`.",
+		)
+	}
+}
+
+// Resolve the function ID from the function name, the name must identify a single function.
+func (d *NvidiaCloudFunctionDataSource) lookupFunctionID(ctx context.Context, diag *diag.Diagnostics, functionName string) string {
+	listNvidiaCloudFunctionsResponse, err := d.client.ListNvidiaCloudFunctions(ctx, []string{"private", "authorized"})
+
+	if err != nil {
+		diag.AddError(
+			"Failed to list Cloud Functions",
+			utils.ErrorDetail(err),
+		)
+		return ""
+	}
+
+	functionIDs := make([]string, 0)
+	for _, f := range listNvidiaCloudFunctionsResponse.Functions {
+		if f.Name == functionName && !slices.Contains(functionIDs, f.ID) {
+			functionIDs = append(functionIDs, f.ID)
+		}
+	}
+
+	switch len(functionIDs) {
+	case 0:
+		diag.AddAttributeError(
+			path.Root("function_name"),
+			"Function Not Found Error",
+			fmt.Sprintf("Unable to find a function named %s", functionName),
+		)
+		return ""
+	case 1:
+		return functionIDs[0]
+	default:
+		diag.AddAttributeError(
+			path.Root("function_name"),
+			"Multiple Functions Found Error",
+			fmt.Sprintf("Found %d functions named %s: %s. Please set `function_id` as well", len(functionIDs), functionName, strings.Join(functionIDs, ", ")),
+		)
+		return ""
+	}
+}
+
 func (d *NvidiaCloudFunctionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data NvidiaCloudFunctionDataSourceModel
 
@@ -327,52 +374,91 @@ func (d *NvidiaCloudFunctionDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 
-	var listNvidiaCloudFunctionVersionsResponse, err = d.client.ListNvidiaCloudFunctionVersions(ctx, data.FunctionID.ValueString())
+	d.validateVersionLookup(&resp.Diagnostics, &data)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	functionID := data.FunctionID.ValueString()
+	if functionID == "" {
+		functionID = d.lookupFunctionID(ctx, &resp.Diagnostics, data.FunctionName.ValueString())
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var listNvidiaCloudFunctionVersionsResponse, err = d.client.ListNvidiaCloudFunctionVersions(ctx, functionID)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to read Cloud Function versions",
-			"Got unexpected result when reading Cloud Function",
+			fmt.Sprintf("Got unexpected result when reading Cloud Function: %s", utils.ErrorDetail(err)),
 		)
 		return
 	}
 
-	versionNotFound := true
 	var functionVersion utils.NvidiaCloudFunctionInfo
 
-	for _, f := range listNvidiaCloudFunctionVersionsResponse.Functions {
-		if f.ID == data.FunctionID.ValueString() && f.VersionID == data.VersionID.ValueString() {
-			functionVersion = f
-			versionNotFound = false
-			break
+	if data.VersionID.ValueString() != "" {
+		versionNotFound := true
+
+		for _, f := range listNvidiaCloudFunctionVersionsResponse.Functions {
+			if f.ID == functionID && f.VersionID == data.VersionID.ValueString() {
+				functionVersion = f
+				versionNotFound = false
+				break
+			}
 		}
-	}
 
-	if versionNotFound {
-		resp.Diagnostics.AddError("Version ID Not Found Error", fmt.Sprintf("Unable to find the target version ID %s", data.VersionID.ValueString()))
-		return
-	}
+		if versionNotFound {
+			resp.Diagnostics.AddError("Version ID Not Found Error", fmt.Sprintf("Unable to find the target version ID %s", data.VersionID.ValueString()))
+			return
+		}
+	} else {
+		versions := make([]utils.NvidiaCloudFunctionInfo, 0, len(listNvidiaCloudFunctionVersionsResponse.Functions))
+		for _, f := range listNvidiaCloudFunctionVersionsResponse.Functions {
+			if data.FunctionName.ValueString() == "" || f.Name == data.FunctionName.ValueString() {
+				versions = append(versions, f)
+			}
+		}
 
-	readNvidiaCloudFunctionDeploymentResponse, err := d.client.ReadNvidiaCloudFunctionDeployment(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+		versionSelector := utils.VersionSelectorLatest
+		if data.VersionSelector.ValueString() != "" {
+			versionSelector = data.VersionSelector.ValueString()
+		}
 
-	if err != nil {
-		// FIXME: extract error messsage to constants.
-		if err.Error() != "failed to find function deployment" {
-			resp.Diagnostics.AddError(
-				"Failed to read Cloud Function deployment",
-				err.Error(),
-			)
+		selectedVersion, err := utils.SelectNvidiaCloudFunctionVersion(versions, versionSelector)
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("version_selector"), "Invalid Version Selector", err.Error())
+			return
+		}
+
+		if selectedVersion == nil {
+			resp.Diagnostics.AddError("Version Not Found Error", fmt.Sprintf("Unable to find a %q version of function %s", versionSelector, functionID))
 			return
 		}
+		functionVersion = *selectedVersion
+	}
+
+	readNvidiaCloudFunctionDeploymentResponse, err := d.client.ReadNvidiaCloudFunctionDeployment(ctx, functionVersion.ID, functionVersion.VersionID)
+
+	if err != nil && !utils.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to read Cloud Function deployment",
+			utils.ErrorDetail(err),
+		)
+		return
 	}
 
-	getFunctionAuthorizationResponse, err := d.client.GetFunctionAuthorization(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+	getFunctionAuthorizationResponse, err := d.client.GetFunctionAuthorization(ctx, functionVersion.ID, functionVersion.VersionID)
 
 	if err != nil {
-		// FIXME: extract error messsage to constants.
 		resp.Diagnostics.AddError(
 			"Failed to read Cloud Function authorized parties",
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
 		return
 	}
@@ -15,6 +15,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -118,3 +119,58 @@ func TestAccCloudFunctionDataSource_ContainerBasedFunction(t *testing.T) {
 		},
 	})
 }
+
+func TestAccCloudFunctionDataSource_VersionSelector(t *testing.T) {
+
+	functionInfo := testutils.CreateHelmFunction(t)
+	defer testutils.DeleteFunction(t, functionInfo.Function.ID, functionInfo.Function.VersionID)
+
+	testutils.CreateDeployment(t, functionInfo.Function.ID, functionInfo.Function.VersionID, testutils.TestHelmValueOverWrite)
+
+	versionSelectorConfig := func(versionSelector string) string {
+		return fmt.Sprintf(`
+			data "ngc_cloud_function" "%s" {
+			function_id      = "%s"
+			version_selector = "%s"
+			}
+			`,
+			testCloudFunctionDatasourceName, functionInfo.Function.ID, versionSelector)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: versionSelectorConfig("latest"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "function_id", functionInfo.Function.ID),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "version_id", functionInfo.Function.VersionID),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "function_name", testutils.TestHelmFunctionName),
+				),
+			},
+			{
+				Config: versionSelectorConfig("latest_active"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "version_id", functionInfo.Function.VersionID),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "deployment_specifications.0.gpu_type", testutils.TestGpuType),
+				),
+			},
+			{
+				Config:      versionSelectorConfig("newest"),
+				ExpectError: regexp.MustCompile("Invalid Version Selector"),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "ngc_cloud_function" "%s" {
+					function_id      = "%s"
+					version_id       = "%s"
+					version_selector = "latest"
+					}
+					`,
+					testCloudFunctionDatasourceName, functionInfo.Function.ID, functionInfo.Function.VersionID),
+				ExpectError: regexp.MustCompile("Conflicting Version Lookup"),
+			},
+		},
+	})
+}
@@ -0,0 +1,371 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NvidiaCloudFunctionDeploymentResource{}
+var _ resource.ResourceWithImportState = &NvidiaCloudFunctionDeploymentResource{}
+
+func NewNvidiaCloudFunctionDeploymentResource() resource.Resource {
+	return &NvidiaCloudFunctionDeploymentResource{}
+}
+
+// NvidiaCloudFunctionDeploymentResource defines the resource implementation.
+type NvidiaCloudFunctionDeploymentResource struct {
+	client *utils.NVCFClient
+}
+
+// NvidiaCloudFunctionDeploymentResourceModel describes the resource data model.
+type NvidiaCloudFunctionDeploymentResourceModel struct {
+	Id                       types.String   `tfsdk:"id"`
+	FunctionID               types.String   `tfsdk:"function_id"`
+	VersionID                types.String   `tfsdk:"version_id"`
+	FunctionStatus           types.String   `tfsdk:"function_status"`
+	DeploymentSpecifications types.List     `tfsdk:"deployment_specifications"`
+	Timeouts                 timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) updateNvidiaCloudFunctionDeploymentResourceModel(
+	ctx context.Context, diag *diag.Diagnostics,
+	data *NvidiaCloudFunctionDeploymentResourceModel,
+	functionDeployment *utils.NvidiaCloudFunctionDeployment,
+) {
+	data.Id = types.StringValue(fmt.Sprintf("%s,%s", data.FunctionID.ValueString(), data.VersionID.ValueString()))
+
+	if functionDeployment.FunctionStatus != "" {
+		data.FunctionStatus = types.StringValue(functionDeployment.FunctionStatus)
+	}
+
+	if functionDeployment.DeploymentSpecifications != nil {
+		data.DeploymentSpecifications = deploymentSpecificationsListValue(ctx, functionDeployment.DeploymentSpecifications, diag)
+	}
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_function_deployment"
+}
+
+const deploymentRedeployDescription = "Changing the instance type, GPU type or configuration of a backend recreates the deployment."
+
+func (r *NvidiaCloudFunctionDeploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	deploymentSpecifications := deploymentSpecificationsSchema()
+	deploymentSpecifications.Optional = false
+	deploymentSpecifications.Required = true
+	deploymentSpecifications.PlanModifiers = []planmodifier.List{
+		// The resource is the deployment, moving it elsewhere recreates it.
+		listplanmodifier.RequiresReplaceIf(
+			func(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+				planned := prepareDeploymentSpecifications(ctx, req.PlanValue, &resp.Diagnostics)
+				current := prepareDeploymentSpecifications(ctx, req.StateValue, &resp.Diagnostics)
+				resp.RequiresReplace = deploymentRedeployRequired(planned, current)
+			},
+			deploymentRedeployDescription,
+			deploymentRedeployDescription,
+		),
+	}
+	deploymentSpecifications.MarkdownDescription = "Deployment specifications of the function version, one per backend"
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Nvidia Cloud Function Deployment Resource. Manages the deployment of a function version independently " +
+			"from `ngc_cloud_function`, which must then leave `deployment_specifications` unset.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Read-only identifier with format `function_id,version_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"function_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Function ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Function Version ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"function_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Function version status reported by the deployment",
+			},
+			"deployment_specifications": deploymentSpecifications,
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = ngcClient.NVCFClient()
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NvidiaCloudFunctionDeploymentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	deploymentSpecifications := prepareDeploymentSpecifications(ctx, data.DeploymentSpecifications, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createNvidiaCloudFunctionDeploymentResponse, err := r.client.CreateNvidiaCloudFunctionDeployment(
+		ctx, data.FunctionID.ValueString(), data.VersionID.ValueString(),
+		utils.CreateNvidiaCloudFunctionDeploymentRequest{
+			DeploymentSpecifications: deploymentSpecifications,
+		},
+	)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create Cloud Function Deployment",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	r.updateNvidiaCloudFunctionDeploymentResourceModel(ctx, &resp.Diagnostics, &data, &createNvidiaCloudFunctionDeploymentResponse.Deployment)
+
+	err = r.client.WaitingDeploymentCompleted(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+	if err != nil {
+		// Save the state anyway so that the failed deployment is tainted and replaced on the next apply.
+		resp.Diagnostics.AddError(
+			"Failed to create Cloud Function Deployment",
+			utils.ErrorDetail(err),
+		)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	data.FunctionStatus = types.StringValue("ACTIVE")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NvidiaCloudFunctionDeploymentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+
+	if err != nil && !utils.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to read Cloud Function Deployment",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	if err != nil || len(readNvidiaCloudFunctionDeploymentResponse.Deployment.DeploymentSpecifications) == 0 {
+		tflog.Warn(ctx, fmt.Sprintf("Cloud Function deployment %s/%s no longer exists, removing from state", data.FunctionID.ValueString(), data.VersionID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.updateNvidiaCloudFunctionDeploymentResourceModel(ctx, &resp.Diagnostics, &data, &readNvidiaCloudFunctionDeploymentResponse.Deployment)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan NvidiaCloudFunctionDeploymentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	deploymentSpecifications := prepareDeploymentSpecifications(ctx, plan.DeploymentSpecifications, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateNvidiaCloudFunctionDeploymentResponse, err := r.client.UpdateNvidiaCloudFunctionDeployment(
+		ctx, plan.FunctionID.ValueString(), plan.VersionID.ValueString(),
+		utils.UpdateNvidiaCloudFunctionDeploymentRequest{
+			DeploymentSpecifications: deploymentSpecifications,
+		},
+	)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to update Cloud Function Deployment",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	r.updateNvidiaCloudFunctionDeploymentResourceModel(ctx, &resp.Diagnostics, &plan, &updateNvidiaCloudFunctionDeploymentResponse.Deployment)
+
+	// A scaled deployment stays ACTIVE, wait for its instances instead of its status.
+	err = r.client.WaitingDeploymentScaled(ctx, plan.FunctionID.ValueString(), plan.VersionID.ValueString(), deploymentSpecifications)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to update Cloud Function Deployment",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, plan.FunctionID.ValueString(), plan.VersionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read Cloud Function Deployment",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	r.updateNvidiaCloudFunctionDeploymentResourceModel(ctx, &resp.Diagnostics, &plan, &readNvidiaCloudFunctionDeploymentResponse.Deployment)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NvidiaCloudFunctionDeploymentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.client.DeleteNvidiaCloudFunctionDeployment(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+	if err != nil && !utils.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Failed to delete Cloud Function Deployment %s", data.VersionID.ValueString()),
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	// A replacement deployment is only created once this one is gone.
+	err = r.client.WaitingDeploymentDeleted(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Failed to delete Cloud Function Deployment %s", data.VersionID.ValueString()),
+			utils.ErrorDetail(err),
+		)
+	}
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: function_id,version_id. Got: %q", req.ID),
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("function_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version_id"), idParts[1])...)
+}
@@ -0,0 +1,106 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build !unittest
+// +build !unittest
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/testutils"
+)
+
+func TestAccCloudFunctionDeploymentResource_HelmBasedFunction(t *testing.T) {
+	var testCloudFunctionDeploymentResourceName = "terraform-cloud-function-deployment-integ-resource"
+	var testCloudFunctionDeploymentResourceFullPath = fmt.Sprintf("ngc_cloud_function_deployment.%s", testCloudFunctionDeploymentResourceName)
+
+	functionInfo := testutils.CreateHelmFunction(t)
+	defer testutils.DeleteFunction(t, functionInfo.Function.ID, functionInfo.Function.VersionID)
+
+	deploymentConfig := func(maxInstances int, maxRequestConcurrency int) string {
+		return fmt.Sprintf(`
+			resource "ngc_cloud_function_deployment" "%s" {
+				function_id = "%s"
+				version_id  = "%s"
+				deployment_specifications = [
+					{
+						configuration           = "%s"
+						backend                 = "%s"
+						instance_type           = "%s"
+						gpu_type                = "%s"
+						max_instances           = %d
+						min_instances           = 1
+						max_request_concurrency = %d
+					}
+				]
+			}
+			`,
+			testCloudFunctionDeploymentResourceName,
+			functionInfo.Function.ID,
+			functionInfo.Function.VersionID,
+			testutils.EscapeJSON(t, testutils.TestHelmValueOverWrite),
+			testutils.TestBackend,
+			testutils.TestInstanceType,
+			testutils.TestGpuType,
+			maxInstances,
+			maxRequestConcurrency,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify Deployment Creation
+			{
+				Config: deploymentConfig(1, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "id", fmt.Sprintf("%s,%s", functionInfo.Function.ID, functionInfo.Function.VersionID)),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "function_id", functionInfo.Function.ID),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "version_id", functionInfo.Function.VersionID),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "function_status", "ACTIVE"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.#", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.gpu_type", testutils.TestGpuType),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.backend", testutils.TestBackend),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.instance_type", testutils.TestInstanceType),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.max_instances", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.min_instances", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.max_request_concurrency", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.configuration", testutils.TestHelmValueOverWrite),
+				),
+			},
+			// Verify Deployment Update
+			{
+				Config: deploymentConfig(2, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "function_status", "ACTIVE"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.#", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.max_instances", "2"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.min_instances", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.max_request_concurrency", "2"),
+				),
+			},
+			// Verify Deployment Import
+			{
+				ResourceName:      testCloudFunctionDeploymentResourceFullPath,
+				ImportStateId:     fmt.Sprintf("%s,%s", functionInfo.Function.ID, functionInfo.Function.VersionID),
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"timeouts",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,173 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func deploymentsSchema() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		MarkdownDescription: "Deployment specifications of the function version keyed by backend, such as `{ \"GFN\" = { ... } }`. " +
+			"An alternative to `deployment_specifications`, the plan shows the backends added, removed or scaled and reordering them changes nothing. " +
+			"Backends are updated or redeployed like in `deployment_specifications`.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"configuration": schema.StringAttribute{
+					MarkdownDescription: "Will be the JSON or YAML definition to overwrite the existing values.yaml file when deploying Helm-Based Functions. " +
+						"Configurations with the same content are equal, whatever their format, whitespace or key order.",
+					CustomType: DeploymentConfigurationType{},
+					Optional:   true,
+				},
+				"instance_type": schema.StringAttribute{
+					MarkdownDescription: "NVCF Backend Instance Type.",
+					Required:            true,
+				},
+				"gpu_type": schema.StringAttribute{
+					MarkdownDescription: "GPU Type, GFN backend default is L40",
+					Required:            true,
+				},
+				"max_instances": schema.Int64Attribute{
+					MarkdownDescription: "Max Instances Count",
+					Required:            true,
+				},
+				"min_instances": schema.Int64Attribute{
+					MarkdownDescription: "Min Instances Count",
+					Required:            true,
+				},
+				"max_request_concurrency": schema.Int64Attribute{
+					MarkdownDescription: "Max Concurrency Count",
+					Required:            true,
+				},
+			},
+		},
+		Optional: true,
+	}
+}
+
+// prepareDeployments converts the deployments map to deployment specifications, sorted by backend.
+func prepareDeployments(
+	ctx context.Context,
+	deploymentsRawData basetypes.MapValue,
+	diag *diag.Diagnostics,
+) []utils.NvidiaCloudFunctionDeploymentSpecification {
+	if deploymentsRawData.IsNull() || len(deploymentsRawData.Elements()) == 0 {
+		return nil
+	}
+
+	deployments := make(map[string]NvidiaCloudFunctionResourceDeploymentModel, len(deploymentsRawData.Elements()))
+	diag.Append(deploymentsRawData.ElementsAs(ctx, &deployments, false)...)
+
+	if diag.HasError() {
+		return nil
+	}
+
+	backends := make([]string, 0, len(deployments))
+	for backend := range deployments {
+		backends = append(backends, backend)
+	}
+	sort.Strings(backends)
+
+	deploymentSpecificationsOption := make([]utils.NvidiaCloudFunctionDeploymentSpecification, 0, len(backends))
+	for _, backend := range backends {
+		v := deployments[backend]
+
+		configuration, err := v.Configuration.Unmarshal()
+		if err != nil {
+			diag.AddError(
+				"Failed to parse deployment configuration",
+				err.Error(),
+			)
+			return nil
+		}
+
+		deploymentSpecificationsOption = append(deploymentSpecificationsOption, utils.NvidiaCloudFunctionDeploymentSpecification{
+			Backend:               backend,
+			InstanceType:          v.InstanceType.ValueString(),
+			Gpu:                   v.GpuType.ValueString(),
+			MaxInstances:          int(v.MaxInstances.ValueInt64()),
+			MinInstances:          int(v.MinInstances.ValueInt64()),
+			MaxRequestConcurrency: int(v.MaxRequestConcurrency.ValueInt64()),
+			Configuration:         configuration,
+		})
+	}
+
+	return deploymentSpecificationsOption
+}
+
+func deploymentsMapValue(
+	ctx context.Context,
+	deploymentSpecificationsResponse []utils.NvidiaCloudFunctionDeploymentSpecification,
+	diag *diag.Diagnostics,
+) basetypes.MapValue {
+	deployments := make(map[string]NvidiaCloudFunctionResourceDeploymentModel, len(deploymentSpecificationsResponse))
+	for _, v := range deploymentSpecificationsResponse {
+		if _, ok := deployments[v.Backend]; ok {
+			diag.AddWarning(
+				"Duplicate Cloud Function deployment backend",
+				fmt.Sprintf("The deployment has several specifications for backend %q, only the last one is kept in deployments. Use deployment_specifications instead.", v.Backend),
+			)
+		}
+
+		deployment := NvidiaCloudFunctionResourceDeploymentModel{
+			InstanceType:          types.StringValue(v.InstanceType),
+			GpuType:               types.StringValue(v.Gpu),
+			MaxInstances:          types.Int64Value(int64(v.MaxInstances)),
+			MinInstances:          types.Int64Value(int64(v.MinInstances)),
+			MaxRequestConcurrency: types.Int64Value(int64(v.MaxRequestConcurrency)),
+		}
+
+		if v.Configuration != nil {
+			configuration, _ := json.Marshal(v.Configuration)
+			deployment.Configuration = NewDeploymentConfigurationValue(string(configuration))
+		}
+
+		deployments[v.Backend] = deployment
+	}
+	deploymentsMapType, deploymentsMapTypeDiag := types.MapValueFrom(ctx, deploymentsSchema().NestedObject.Type(), deployments)
+	diag.Append(deploymentsMapTypeDiag...)
+	return deploymentsMapType
+}
+
+// deploymentSpecifications returns the deployment specifications of deployments, or of deployment_specifications when it is not set.
+func (m NvidiaCloudFunctionResourceModel) deploymentSpecifications(ctx context.Context, diag *diag.Diagnostics) []utils.NvidiaCloudFunctionDeploymentSpecification {
+	if !m.Deployments.IsNull() {
+		return prepareDeployments(ctx, m.Deployments, diag)
+	}
+	return prepareDeploymentSpecifications(ctx, m.DeploymentSpecifications, diag)
+}
+
+// hasDeployment reports whether the function version is deployed by this resource.
+func (m NvidiaCloudFunctionResourceModel) hasDeployment() bool {
+	return len(m.DeploymentSpecifications.Elements()) > 0 || len(m.Deployments.Elements()) > 0
+}
+
+// setDeploymentSpecifications sets the deployment specifications of the response to the form used by the configuration.
+func (m *NvidiaCloudFunctionResourceModel) setDeploymentSpecifications(
+	ctx context.Context,
+	deploymentSpecificationsResponse []utils.NvidiaCloudFunctionDeploymentSpecification,
+	diag *diag.Diagnostics,
+) {
+	if !m.Deployments.IsNull() {
+		m.Deployments = deploymentsMapValue(ctx, deploymentSpecificationsResponse, diag)
+		return
+	}
+	m.DeploymentSpecifications = deploymentSpecificationsListValue(ctx, deploymentSpecificationsResponse, diag)
+}
@@ -0,0 +1,275 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/fakenvcf"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func testDeploymentSpecification(backend string, gpu string, maxInstances int) utils.NvidiaCloudFunctionDeploymentSpecification {
+	return utils.NvidiaCloudFunctionDeploymentSpecification{
+		Gpu:                   gpu,
+		Backend:               backend,
+		InstanceType:          "GPU." + gpu + "_1x",
+		MinInstances:          1,
+		MaxInstances:          maxInstances,
+		MaxRequestConcurrency: 1,
+	}
+}
+
+func TestPrepareDeployments(t *testing.T) {
+	t.Parallel()
+
+	deployment := func(gpu string, maxInstances int64, configuration string) NvidiaCloudFunctionResourceDeploymentModel {
+		model := NvidiaCloudFunctionResourceDeploymentModel{
+			GpuType:               types.StringValue(gpu),
+			InstanceType:          types.StringValue("GPU." + gpu + "_1x"),
+			MinInstances:          types.Int64Value(1),
+			MaxInstances:          types.Int64Value(maxInstances),
+			MaxRequestConcurrency: types.Int64Value(1),
+			Configuration:         NewDeploymentConfigurationNull(),
+		}
+		if configuration != "" {
+			model.Configuration = NewDeploymentConfigurationValue(configuration)
+		}
+		return model
+	}
+
+	withConfiguration := testDeploymentSpecification("gfn", "L40", 2)
+	withConfiguration.Configuration = map[string]interface{}{"replicas": float64(2)}
+
+	tests := []struct {
+		name        string
+		deployments map[string]NvidiaCloudFunctionResourceDeploymentModel
+		want        []utils.NvidiaCloudFunctionDeploymentSpecification
+	}{
+		{
+			name:        "Null",
+			deployments: nil,
+			want:        nil,
+		},
+		{
+			name: "SortedByBackend",
+			deployments: map[string]NvidiaCloudFunctionResourceDeploymentModel{
+				"gfn":  deployment("L40", 2, ""),
+				"az33": deployment("H100", 1, ""),
+				"dgxc": deployment("A100", 3, ""),
+			},
+			want: []utils.NvidiaCloudFunctionDeploymentSpecification{
+				testDeploymentSpecification("az33", "H100", 1),
+				testDeploymentSpecification("dgxc", "A100", 3),
+				testDeploymentSpecification("gfn", "L40", 2),
+			},
+		},
+		{
+			name: "YAMLConfiguration",
+			deployments: map[string]NvidiaCloudFunctionResourceDeploymentModel{
+				"gfn": deployment("L40", 2, "replicas: 2\n"),
+			},
+			want: []utils.NvidiaCloudFunctionDeploymentSpecification{withConfiguration},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			var diags diag.Diagnostics
+
+			deployments := types.MapNull(deploymentsSchema().NestedObject.Type())
+			if tt.deployments != nil {
+				deployments, diags = types.MapValueFrom(ctx, deploymentsSchema().NestedObject.Type(), tt.deployments)
+				if diags.HasError() {
+					t.Fatalf("MapValueFrom() diagnostics = %v", diags)
+				}
+			}
+
+			got := prepareDeployments(ctx, deployments, &diags)
+			assert.False(t, diags.HasError(), "prepareDeployments() diagnostics = %v", diags)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeploymentsMapValue(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	specifications := []utils.NvidiaCloudFunctionDeploymentSpecification{
+		testDeploymentSpecification("gfn", "L40", 2),
+		testDeploymentSpecification("az33", "H100", 1),
+	}
+
+	var diags diag.Diagnostics
+	deployments := deploymentsMapValue(ctx, specifications, &diags)
+	assert.False(t, diags.HasError(), "deploymentsMapValue() diagnostics = %v", diags)
+	assert.Equal(t, []utils.NvidiaCloudFunctionDeploymentSpecification{specifications[1], specifications[0]}, prepareDeployments(ctx, deployments, &diags))
+
+	deploymentsMapValue(ctx, append(specifications, testDeploymentSpecification("gfn", "T10", 1)), &diags)
+	assert.Len(t, diags.Warnings(), 1)
+}
+
+func TestDeploymentRedeployRequired(t *testing.T) {
+	t.Parallel()
+
+	withConfiguration := testDeploymentSpecification("gfn", "L40", 2)
+	withConfiguration.Configuration = map[string]interface{}{"replicas": float64(2)}
+
+	tests := []struct {
+		name    string
+		planned []utils.NvidiaCloudFunctionDeploymentSpecification
+		current []utils.NvidiaCloudFunctionDeploymentSpecification
+		want    bool
+	}{
+		{
+			name:    "NotDeployed",
+			planned: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "L40", 2)},
+			want:    false,
+		},
+		{
+			name:    "Scaled",
+			planned: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "L40", 4)},
+			current: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "L40", 2)},
+			want:    false,
+		},
+		{
+			name:    "Reordered",
+			planned: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "L40", 2), testDeploymentSpecification("az33", "H100", 1)},
+			current: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("az33", "H100", 1), testDeploymentSpecification("gfn", "L40", 2)},
+			want:    false,
+		},
+		{
+			name:    "BackendAdded",
+			planned: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("az33", "H100", 1), testDeploymentSpecification("gfn", "L40", 2)},
+			current: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "L40", 2)},
+			want:    false,
+		},
+		{
+			name:    "BackendRemoved",
+			planned: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "L40", 2)},
+			current: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("az33", "H100", 1), testDeploymentSpecification("gfn", "L40", 2)},
+			want:    false,
+		},
+		{
+			name:    "GpuChanged",
+			planned: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "T10", 2)},
+			current: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "L40", 2)},
+			want:    true,
+		},
+		{
+			name:    "ConfigurationChanged",
+			planned: []utils.NvidiaCloudFunctionDeploymentSpecification{withConfiguration},
+			current: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "L40", 2)},
+			want:    true,
+		},
+		{
+			name:    "SpecificationAddedToBackend",
+			planned: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "L40", 2), testDeploymentSpecification("gfn", "T10", 1)},
+			current: []utils.NvidiaCloudFunctionDeploymentSpecification{testDeploymentSpecification("gfn", "L40", 2)},
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, deploymentRedeployRequired(tt.planned, tt.current))
+		})
+	}
+}
+
+func TestNvidiaCloudFunctionDeploymentResource_UpdateScaled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakenvcf.NewServer(fakenvcf.Config{DeploymentDuration: 100 * time.Millisecond})
+	defer server.Close()
+
+	client := &utils.NVCFClient{
+		NgcEndpoint: server.URL(),
+		NgcApiKey:   server.Config().APIKey,
+		NgcOrg:      server.Config().Org,
+		HttpClient:  http.DefaultClient,
+		DeploymentPollPolicy: utils.DeploymentPollPolicy{
+			Interval: 10 * time.Millisecond,
+		},
+	}
+	r := &NvidiaCloudFunctionDeploymentResource{client: client}
+
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", utils.CreateNvidiaCloudFunctionRequest{
+		FunctionName:   "scaled",
+		ContainerImage: "nvcr.io/mock-org/echo:0.1",
+		InferenceUrl:   "/echo",
+		InferencePort:  8000,
+	})
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	functionID, versionID := created.Function.ID, created.Function.VersionID
+
+	specification := testDeploymentSpecification("fakenvcf-backend", "L40", 3)
+	_, err = client.CreateNvidiaCloudFunctionDeployment(ctx, functionID, versionID, utils.CreateNvidiaCloudFunctionDeploymentRequest{
+		DeploymentSpecifications: []utils.NvidiaCloudFunctionDeploymentSpecification{specification},
+	})
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunctionDeployment() error = %v", err)
+	}
+	if err := client.WaitingDeploymentCompleted(ctx, functionID, versionID); err != nil {
+		t.Fatalf("WaitingDeploymentCompleted() error = %v", err)
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// Scaling the ACTIVE deployment keeps it ACTIVE while the new instances start.
+	specification.MinInstances = 2
+	var diags diag.Diagnostics
+	deploymentSpecifications := deploymentSpecificationsListValue(ctx, []utils.NvidiaCloudFunctionDeploymentSpecification{specification}, &diags)
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags.Append(plan.SetAttribute(ctx, path.Root("function_id"), functionID)...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("version_id"), versionID)...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("deployment_specifications"), deploymentSpecifications)...)
+	if diags.HasError() {
+		t.Fatalf("plan diagnostics = %v", diags)
+	}
+
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan}, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), "Update() diagnostics = %v", resp.Diagnostics)
+
+	// The update only completes once the instances are scaled.
+	got, err := client.GetNvidiaCloudFunctionVersion(ctx, functionID, versionID)
+	assert.NoError(t, err)
+	assert.Len(t, got.Function.ActiveInstances, 2)
+
+	var functionStatus types.String
+	resp.State.GetAttribute(ctx, path.Root("function_status"), &functionStatus)
+	assert.Equal(t, fakenvcf.StatusActive, functionStatus.ValueString())
+}
@@ -0,0 +1,456 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NvidiaCloudFunctionFamilyResource{}
+var _ resource.ResourceWithImportState = &NvidiaCloudFunctionFamilyResource{}
+var _ resource.ResourceWithValidateConfig = &NvidiaCloudFunctionFamilyResource{}
+var _ resource.ResourceWithModifyPlan = &NvidiaCloudFunctionFamilyResource{}
+
+func NewNvidiaCloudFunctionFamilyResource() resource.Resource {
+	return &NvidiaCloudFunctionFamilyResource{}
+}
+
+// NvidiaCloudFunctionFamilyResource defines the resource implementation.
+// It owns the function, whose versions are managed by ngc_cloud_function resources with its function_id.
+type NvidiaCloudFunctionFamilyResource struct {
+	client *utils.NVCFClient
+}
+
+// NvidiaCloudFunctionFamilyResourceModel describes the resource data model.
+type NvidiaCloudFunctionFamilyResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	FunctionName     types.String `tfsdk:"function_name"`
+	InitialVersion   types.Object `tfsdk:"initial_version"`
+	RetainedVersions types.Int64  `tfsdk:"retained_versions"`
+	VersionIDs       types.List   `tfsdk:"version_ids"`
+}
+
+type NvidiaCloudFunctionFamilyInitialVersionModel struct {
+	HelmChart            types.String `tfsdk:"helm_chart"`
+	HelmChartServiceName types.String `tfsdk:"helm_chart_service_name"`
+	ContainerImage       types.String `tfsdk:"container_image"`
+	InferencePort        types.Int64  `tfsdk:"inference_port"`
+	InferenceUrl         types.String `tfsdk:"inference_url"`
+	APIBodyFormat        types.String `tfsdk:"api_body_format"`
+}
+
+func (r *NvidiaCloudFunctionFamilyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_function_family"
+}
+
+func (r *NvidiaCloudFunctionFamilyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Nvidia Cloud Function Family Resource. Owns a function and its name, while its versions are managed by " +
+			"`ngc_cloud_function` resources with its `function_id`. Every remaining version of the function is deleted on destroy.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Read-only Function ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"function_name": schema.StringAttribute{
+				MarkdownDescription: "Function name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"initial_version": schema.SingleNestedAttribute{
+				MarkdownDescription: "First version of the function, which is not deployed. NVCF creates a function with its first version, " +
+					"this version is only used to create the function, changing it afterwards updates nothing.",
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"helm_chart": schema.StringAttribute{
+						MarkdownDescription: "Helm chart registry uri. Conflicts with `container_image`.",
+						Optional:            true,
+					},
+					"helm_chart_service_name": schema.StringAttribute{
+						MarkdownDescription: "Target service name",
+						Optional:            true,
+					},
+					"container_image": schema.StringAttribute{
+						MarkdownDescription: "Container image uri. Conflicts with `helm_chart`.",
+						Optional:            true,
+					},
+					"inference_port": schema.Int64Attribute{
+						MarkdownDescription: "Target port, will be service port or container port base on function-based",
+						Optional:            true,
+					},
+					"inference_url": schema.StringAttribute{
+						MarkdownDescription: "Service endpoint Path.",
+						Required:            true,
+					},
+					"api_body_format": schema.StringAttribute{
+						MarkdownDescription: "API Body Format. Default is \"CUSTOM\"",
+						Optional:            true,
+					},
+				},
+			},
+			"retained_versions": schema.Int64Attribute{
+				MarkdownDescription: "Number of old versions kept, the older ones are deleted on apply, the resource being updated as soon as " +
+					"old versions are beyond it. The old versions are the versions replaced by a rollout of an `ngc_cloud_function` which could not " +
+					"delete them, tagged `terraform-replaced-version`, and are only deleted once they have no deployment. " +
+					"Every version is kept when unset.",
+				Optional: true,
+			},
+			"version_ids": schema.ListAttribute{
+				MarkdownDescription: "Read-only IDs of the versions of the function, from the oldest to the newest",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NvidiaCloudFunctionFamilyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NvidiaCloudFunctionFamilyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.RetainedVersions.IsNull() && !data.RetainedVersions.IsUnknown() && data.RetainedVersions.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retained_versions"),
+			"Invalid retained_versions Configuration",
+			fmt.Sprintf("retained_versions must be a non-negative number of versions, got %d.", data.RetainedVersions.ValueInt64()),
+		)
+	}
+
+	if data.InitialVersion.IsNull() || data.InitialVersion.IsUnknown() {
+		return
+	}
+
+	var initialVersion NvidiaCloudFunctionFamilyInitialVersionModel
+	resp.Diagnostics.Append(data.InitialVersion.As(ctx, &initialVersion, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+
+	if resp.Diagnostics.HasError() || initialVersion.HelmChart.IsUnknown() || initialVersion.ContainerImage.IsUnknown() {
+		return
+	}
+
+	if initialVersion.HelmChart.IsNull() == initialVersion.ContainerImage.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("initial_version"),
+			"Invalid initial_version Configuration",
+			"Exactly one of container_image and helm_chart must be set.",
+		)
+	}
+}
+
+// ModifyPlan marks version_ids unknown when the resource is updated with retained_versions, since Update deletes
+// the old versions of the function beyond them. Without changes, the resource is updated only when old versions,
+// such as the ones rollouts could not delete since the last apply, are beyond retained_versions.
+func (r *NvidiaCloudFunctionFamilyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to prune on creation or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan NvidiaCloudFunctionFamilyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.RetainedVersions.IsNull() {
+		return
+	}
+
+	if !req.Plan.Raw.Equal(req.State.Raw) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version_ids"), types.ListUnknown(types.StringType))...)
+		return
+	}
+
+	// The provider is not configured yet when its configuration is unknown.
+	if r.client == nil {
+		return
+	}
+
+	listNvidiaCloudFunctionVersionsResponse, err := r.client.ListNvidiaCloudFunctionVersions(ctx, plan.Id.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to list Cloud Function versions",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	pruned := r.prunedFunctionVersions(ctx, plan.Id.ValueString(), listNvidiaCloudFunctionVersionsResponse.Functions, plan.RetainedVersions, &resp.Diagnostics)
+
+	if len(pruned) > 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version_ids"), types.ListUnknown(types.StringType))...)
+	}
+}
+
+// prunedFunctionVersions returns the IDs of the old versions of the function beyond retained_versions, none when unset.
+func (r *NvidiaCloudFunctionFamilyResource) prunedFunctionVersions(
+	ctx context.Context,
+	functionID string,
+	versions []utils.NvidiaCloudFunctionInfo,
+	retainedVersions types.Int64,
+	diag *diag.Diagnostics,
+) []string {
+	if retainedVersions.IsNull() || retainedVersions.IsUnknown() {
+		return nil
+	}
+
+	deployed, err := deployedFunctionVersionIDs(ctx, r.client, functionID, versions)
+	if err != nil {
+		diag.AddError(
+			"Failed to read Cloud Function Deployment",
+			utils.ErrorDetail(err),
+		)
+		return nil
+	}
+	return prunedFunctionVersions(versions, deployed, int(retainedVersions.ValueInt64()))
+}
+
+func (r *NvidiaCloudFunctionFamilyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = ngcClient.NVCFClient()
+}
+
+func (r *NvidiaCloudFunctionFamilyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NvidiaCloudFunctionFamilyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var initialVersion NvidiaCloudFunctionFamilyInitialVersionModel
+	resp.Diagnostics.Append(data.InitialVersion.As(ctx, &initialVersion, basetypes.ObjectAsOptions{})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := utils.CreateNvidiaCloudFunctionRequest{
+		FunctionName:         data.FunctionName.ValueString(),
+		HelmChart:            initialVersion.HelmChart.ValueString(),
+		HelmChartServiceName: initialVersion.HelmChartServiceName.ValueString(),
+		ContainerImage:       initialVersion.ContainerImage.ValueString(),
+		InferencePort:        int(initialVersion.InferencePort.ValueInt64()),
+		InferenceUrl:         initialVersion.InferenceUrl.ValueString(),
+		APIBodyFormat:        initialVersion.APIBodyFormat.ValueString(),
+	}
+	if request.APIBodyFormat == "" {
+		request.APIBodyFormat = "CUSTOM"
+	}
+
+	createNvidiaCloudFunctionResponse, err := r.client.CreateNvidiaCloudFunction(ctx, "", request)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create Cloud Function",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(createNvidiaCloudFunctionResponse.Function.ID)
+	r.updateNvidiaCloudFunctionFamilyResourceModel(ctx, &resp.Diagnostics, &data, []utils.NvidiaCloudFunctionInfo{createNvidiaCloudFunctionResponse.Function})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NvidiaCloudFunctionFamilyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NvidiaCloudFunctionFamilyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listNvidiaCloudFunctionVersionsResponse, err := r.client.ListNvidiaCloudFunctionVersions(ctx, data.Id.ValueString())
+
+	if err != nil && !utils.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to list Cloud Function versions",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	// A function may be left without any version, it is gone all the same.
+	if err != nil || len(listNvidiaCloudFunctionVersionsResponse.Functions) == 0 {
+		tflog.Warn(ctx, fmt.Sprintf("Cloud Function %s no longer exists, removing from state", data.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.updateNvidiaCloudFunctionFamilyResourceModel(ctx, &resp.Diagnostics, &data, listNvidiaCloudFunctionVersionsResponse.Functions)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NvidiaCloudFunctionFamilyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state NvidiaCloudFunctionFamilyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listNvidiaCloudFunctionVersionsResponse, err := r.client.ListNvidiaCloudFunctionVersions(ctx, state.Id.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to list Cloud Function versions",
+			utils.ErrorDetail(err),
+		)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	versions := listNvidiaCloudFunctionVersionsResponse.Functions
+
+	pruned := r.prunedFunctionVersions(ctx, state.Id.ValueString(), versions, plan.RetainedVersions, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	for _, versionID := range pruned {
+		tflog.Info(ctx, fmt.Sprintf("deleting Cloud Function version %s beyond the retained versions", versionID))
+
+		err := r.client.DeleteNvidiaCloudFunctionVersion(ctx, state.Id.ValueString(), versionID)
+		if err != nil && !utils.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Failed to delete Cloud Function version %s", versionID),
+				utils.ErrorDetail(err),
+			)
+			// The next refresh reads back the versions already deleted.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+
+		versions = slices.DeleteFunc(versions, func(v utils.NvidiaCloudFunctionInfo) bool {
+			return v.VersionID == versionID
+		})
+	}
+
+	r.updateNvidiaCloudFunctionFamilyResourceModel(ctx, &resp.Diagnostics, &plan, versions)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NvidiaCloudFunctionFamilyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NvidiaCloudFunctionFamilyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listNvidiaCloudFunctionVersionsResponse, err := r.client.ListNvidiaCloudFunctionVersions(ctx, data.Id.ValueString())
+
+	if err != nil {
+		if !utils.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Failed to list Cloud Function versions",
+				utils.ErrorDetail(err),
+			)
+		}
+		return
+	}
+
+	// The versions left by the ngc_cloud_function resources, or created outside of Terraform, are deleted with the function.
+	for _, versionID := range functionVersionIDs(listNvidiaCloudFunctionVersionsResponse.Functions) {
+		tflog.Info(ctx, fmt.Sprintf("deleting Cloud Function version %s", versionID))
+
+		err := r.client.DeleteNvidiaCloudFunctionVersion(ctx, data.Id.ValueString(), versionID)
+		if err != nil && !utils.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Failed to delete Cloud Function version %s", versionID),
+				utils.ErrorDetail(err),
+			)
+		}
+	}
+}
+
+func (r *NvidiaCloudFunctionFamilyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *NvidiaCloudFunctionFamilyResource) updateNvidiaCloudFunctionFamilyResourceModel(
+	ctx context.Context, diag *diag.Diagnostics,
+	data *NvidiaCloudFunctionFamilyResourceModel,
+	versions []utils.NvidiaCloudFunctionInfo,
+) {
+	versionIDs := make([]attr.Value, 0, len(versions))
+	for _, v := range functionVersionIDs(versions) {
+		versionIDs = append(versionIDs, types.StringValue(v))
+	}
+
+	versionIDsListType, versionIDsListTypeDiag := types.ListValue(types.StringType, versionIDs)
+	diag.Append(versionIDsListTypeDiag...)
+	data.VersionIDs = versionIDsListType
+
+	// The function name is only null right after import.
+	if data.FunctionName.IsNull() && len(versions) > 0 {
+		data.FunctionName = types.StringValue(versions[0].Name)
+	}
+}
@@ -0,0 +1,198 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build !unittest
+// +build !unittest
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/testutils"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestAccCloudFunctionFamilyResource_ContainerBasedFunction(t *testing.T) {
+	var functionName = uuid.New().String()
+	var testCloudFunctionFamilyResourceFullPath = "ngc_cloud_function_family.family"
+	var testCloudFunctionResourceFullPath = "ngc_cloud_function.version"
+
+	var functionID, initialVersionID, versionID string
+
+	config := func(retainedVersions string) string {
+		return fmt.Sprintf(`
+				resource "ngc_cloud_function_family" "family" {
+					function_name     = "%[1]s"
+					initial_version   = {
+						container_image = "%[2]s"
+						inference_port  = %[3]d
+						inference_url   = "%[4]s"
+					}
+					retained_versions = %[5]s
+				}
+
+				resource "ngc_cloud_function" "version" {
+					function_id             = ngc_cloud_function_family.family.id
+					function_name           = ngc_cloud_function_family.family.function_name
+					container_image         = "%[2]s"
+					inference_port          = %[3]d
+					inference_url           = "%[4]s"
+					health                    = {
+						uri                  = "%[6]s"
+						port                 = %[3]d
+						expected_status_code = 200
+						timeout              = "PT10S"
+						protocol             = "HTTP"
+					}
+					api_body_format         = "%[7]s"
+					deployment_specifications = [
+						{
+							backend                 = "%[8]s"
+							instance_type           = "%[9]s"
+							gpu_type                = "%[10]s"
+							max_instances           = 1
+							min_instances           = 1
+							max_request_concurrency = 1
+						}
+					]
+				}
+				`,
+			functionName,
+			testutils.TestContainerUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerInferenceUrl,
+			retainedVersions,
+			testutils.TestContainerHealthUri,
+			testutils.TestContainerAPIFormat,
+			testutils.TestBackend,
+			testutils.TestInstanceType,
+			testutils.TestGpuType,
+		)
+	}
+
+	// createOutOfBandVersion creates a version with the given tags, such as the tag of a version a rollout could not delete.
+	createOutOfBandVersion := func(tags ...string) string {
+		resp, err := testutils.TestNVCFClient.CreateNvidiaCloudFunction(testutils.Ctx, functionID, utils.CreateNvidiaCloudFunctionRequest{
+			FunctionName:   functionName,
+			ContainerImage: testutils.TestContainerUri,
+			InferencePort:  testutils.TestContainerPort,
+			InferenceUrl:   testutils.TestContainerInferenceUrl,
+			HealthUri:      testutils.TestContainerHealthUri,
+			APIBodyFormat:  testutils.TestContainerAPIFormat,
+			Tags:           tags,
+		})
+		if err != nil {
+			t.Fatalf("Unable to create function version: %s", err.Error())
+		}
+		return resp.Function.VersionID
+	}
+
+	var replacedVersionID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(state *terraform.State) error {
+			resp, err := testutils.TestNVCFClient.ListNvidiaCloudFunctionVersions(testutils.Ctx, functionID)
+			if err != nil {
+				if utils.IsNotFound(err) {
+					return nil
+				}
+				return err
+			}
+			if len(resp.Functions) > 0 {
+				return fmt.Errorf("expected every version of function %s to be deleted, got %d versions", functionID, len(resp.Functions))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Verify retained_versions validation
+			{
+				Config:      config("-1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid retained_versions Configuration"),
+			},
+			// Verify Function Family Creation with a version managed by ngc_cloud_function
+			{
+				Config: config("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionFamilyResourceFullPath, "function_name", functionName),
+					resource.TestCheckResourceAttr(testCloudFunctionFamilyResourceFullPath, "version_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(testCloudFunctionResourceFullPath, "id", testCloudFunctionFamilyResourceFullPath, "id"),
+					func(state *terraform.State) error {
+						functionID = state.RootModule().Resources[testCloudFunctionFamilyResourceFullPath].Primary.Attributes["id"]
+						initialVersionID = state.RootModule().Resources[testCloudFunctionFamilyResourceFullPath].Primary.Attributes["version_ids.0"]
+						versionID = state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes["version_id"]
+						return nil
+					},
+				),
+			},
+			// Verify the versions a rollout could not delete are deleted on update beyond retained_versions, keeping the other ones
+			{
+				PreConfig: func() {
+					replacedVersionID = createOutOfBandVersion(replacedVersionTag)
+					createOutOfBandVersion()
+				},
+				Config: config("0"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(testCloudFunctionFamilyResourceFullPath, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(testCloudFunctionFamilyResourceFullPath, tfjsonpath.New("version_ids")),
+						plancheck.ExpectResourceAction(testCloudFunctionResourceFullPath, plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionFamilyResourceFullPath, "version_ids.#", "3"),
+					resource.TestCheckResourceAttrPtr(testCloudFunctionFamilyResourceFullPath, "version_ids.0", &initialVersionID),
+					func(state *terraform.State) error {
+						resp, err := testutils.TestNVCFClient.ListNvidiaCloudFunctionVersions(testutils.Ctx, functionID)
+						if err != nil {
+							return err
+						}
+						versionIDs := functionVersionIDs(resp.Functions)
+						if slices.Contains(versionIDs, replacedVersionID) || !slices.Contains(versionIDs, versionID) {
+							return fmt.Errorf("expected the replaced version %s to be deleted and the version %s to be kept, got %v", replacedVersionID, versionID, versionIDs)
+						}
+						return nil
+					},
+				),
+			},
+			// Verify the versions created outside of Terraform are read back without any change planned
+			{
+				PreConfig: func() {
+					createOutOfBandVersion()
+				},
+				Config: config("0"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(testCloudFunctionFamilyResourceFullPath, "version_ids.#", "4"),
+			},
+			// Verify Function Family Import
+			{
+				ResourceName:            testCloudFunctionFamilyResourceFullPath,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"initial_version", "retained_versions"},
+			},
+		},
+	})
+}
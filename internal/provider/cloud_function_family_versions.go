@@ -0,0 +1,72 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// functionVersionIDs returns the IDs of the versions of the function from the oldest to the newest.
+func functionVersionIDs(versions []utils.NvidiaCloudFunctionInfo) []string {
+	versionIDs := make([]string, 0, len(versions))
+	for _, v := range utils.SortedFunctionVersions(versions) {
+		versionIDs = append(versionIDs, v.VersionID)
+	}
+	return versionIDs
+}
+
+// isReplacedFunctionVersion tells whether the version was replaced by a rollout of ngc_cloud_function which
+// could not delete it.
+func isReplacedFunctionVersion(v utils.NvidiaCloudFunctionInfo) bool {
+	return slices.Contains(v.Tags, replacedVersionTag)
+}
+
+// prunedFunctionVersions returns the IDs of the old versions of the function beyond the retained ones, from the
+// oldest to the newest. The old versions are the versions replaced by a rollout and left behind without deployment,
+// the deployed ones being the IDs of the versions with a deployment, whatever their status. No other version is
+// ever pruned, such as the version of an ngc_cloud_function without deployment.
+func prunedFunctionVersions(versions []utils.NvidiaCloudFunctionInfo, deployed []string, retained int) []string {
+	old := make([]string, 0)
+	for _, v := range utils.SortedFunctionVersions(versions) {
+		if isReplacedFunctionVersion(v) && !slices.Contains(deployed, v.VersionID) {
+			old = append(old, v.VersionID)
+		}
+	}
+
+	if len(old) <= retained {
+		return nil
+	}
+	return old[:len(old)-retained]
+}
+
+// deployedFunctionVersionIDs returns the IDs of the versions replaced by a rollout which still have a deployment,
+// whatever their status, such as a failed deployment of ngc_cloud_function_deployment. The deployments of the other
+// versions, which are never pruned, are not read.
+func deployedFunctionVersionIDs(ctx context.Context, client *utils.NVCFClient, functionID string, versions []utils.NvidiaCloudFunctionInfo) ([]string, error) {
+	deployed := make([]string, 0)
+	for _, v := range versions {
+		if !isReplacedFunctionVersion(v) {
+			continue
+		}
+
+		readNvidiaCloudFunctionDeploymentResponse, err := client.ReadNvidiaCloudFunctionDeployment(ctx, functionID, v.VersionID)
+		if err != nil && !utils.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil && len(readNvidiaCloudFunctionDeploymentResponse.Deployment.DeploymentSpecifications) > 0 {
+			deployed = append(deployed, v.VersionID)
+		}
+	}
+	return deployed, nil
+}
@@ -0,0 +1,255 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/fakenvcf"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// testFunctionVersions returns versions A, B, C... created one hour apart, the replaced ones being tagged with replacedVersionTag.
+func testFunctionVersions(replaced ...bool) []utils.NvidiaCloudFunctionInfo {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	versions := make([]utils.NvidiaCloudFunctionInfo, 0, len(replaced))
+	for i := range replaced {
+		v := utils.NvidiaCloudFunctionInfo{
+			VersionID: string(rune('A' + i)),
+			Status:    "INACTIVE",
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Hour),
+		}
+		if replaced[i] {
+			v.Tags = []string{"tag1", replacedVersionTag}
+		}
+		versions = append(versions, v)
+	}
+	// The API does not sort the versions.
+	for i, j := 0, len(versions)-1; i < j; i, j = i+1, j-1 {
+		versions[i], versions[j] = versions[j], versions[i]
+	}
+	return versions
+}
+
+func TestFunctionVersionIDs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"A", "B", "C"}, functionVersionIDs(testFunctionVersions(true, false, false)))
+	assert.Equal(t, []string{}, functionVersionIDs(nil))
+}
+
+func TestPrunedFunctionVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		versions []utils.NvidiaCloudFunctionInfo
+		deployed []string
+		retained int
+		want     []string
+	}{
+		{
+			name:     "NoVersion",
+			versions: nil,
+			retained: 0,
+			want:     nil,
+		},
+		{
+			name:     "NoReplacedVersion",
+			versions: testFunctionVersions(false, false),
+			retained: 0,
+			want:     nil,
+		},
+		{
+			name:     "OldestPruned",
+			versions: testFunctionVersions(true, true, true, false),
+			retained: 1,
+			want:     []string{"A", "B"},
+		},
+		{
+			name:     "NotReplacedKept",
+			versions: testFunctionVersions(false, true, false, true),
+			retained: 0,
+			want:     []string{"B", "D"},
+		},
+		{
+			// Such as an ERROR version whose deployment is managed by ngc_cloud_function_deployment.
+			name:     "DeployedKept",
+			versions: testFunctionVersions(true, true, false),
+			deployed: []string{"A"},
+			retained: 0,
+			want:     []string{"B"},
+		},
+		{
+			name:     "Retained",
+			versions: testFunctionVersions(true, true, false),
+			retained: 3,
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, prunedFunctionVersions(tt.versions, tt.deployed, tt.retained))
+		})
+	}
+}
+
+func TestNvidiaCloudFunctionFamilyResource_PruneOnReapply(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := fakenvcf.NewServer(fakenvcf.Config{})
+	defer server.Close()
+
+	client := &utils.NVCFClient{
+		NgcEndpoint: server.URL(),
+		NgcApiKey:   server.Config().APIKey,
+		NgcOrg:      server.Config().Org,
+		HttpClient:  http.DefaultClient,
+	}
+	r := &NvidiaCloudFunctionFamilyResource{client: client}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	createRequest := utils.CreateNvidiaCloudFunctionRequest{
+		FunctionName:   "family",
+		ContainerImage: "nvcr.io/mock-org/echo:0.1",
+		InferenceUrl:   "/echo",
+		InferencePort:  8000,
+	}
+	created, err := client.CreateNvidiaCloudFunction(ctx, "", createRequest)
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	functionID, initialVersionID := created.Function.ID, created.Function.VersionID
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	diags := state.Set(ctx, &NvidiaCloudFunctionFamilyResourceModel{
+		Id:               types.StringValue(functionID),
+		FunctionName:     types.StringValue("family"),
+		InitialVersion:   types.ObjectNull(schemaResp.Schema.Attributes["initial_version"].GetType().(basetypes.ObjectType).AttrTypes),
+		RetainedVersions: types.Int64Value(0),
+		VersionIDs:       types.ListValueMust(types.StringType, []attr.Value{types.StringValue(initialVersionID)}),
+	})
+	if diags.HasError() {
+		t.Fatalf("state diagnostics = %v", diags)
+	}
+
+	deploy := func(versionID string) {
+		t.Helper()
+
+		_, err := client.CreateNvidiaCloudFunctionDeployment(ctx, functionID, versionID, utils.CreateNvidiaCloudFunctionDeploymentRequest{
+			DeploymentSpecifications: []utils.NvidiaCloudFunctionDeploymentSpecification{
+				{Gpu: "L40", Backend: "fakenvcf-backend", InstanceType: "FAKENVCF.GPU.L40_1x", MinInstances: 1, MaxInstances: 1, MaxRequestConcurrency: 1},
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateNvidiaCloudFunctionDeployment() error = %v", err)
+		}
+	}
+
+	// A rollout of an ngc_cloud_function could not delete the version it replaced.
+	replaced, err := client.CreateNvidiaCloudFunction(ctx, functionID, createRequest)
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	server.InjectFault(fakenvcf.Fault{
+		Method:     http.MethodDelete,
+		Path:       "/nvcf/functions/" + functionID + "/versions/" + replaced.Function.VersionID,
+		StatusCode: http.StatusInternalServerError,
+		Times:      1,
+	})
+	var deleteDiags diag.Diagnostics
+	(&NvidiaCloudFunctionResource{client: client}).deletePreviousVersion(ctx, NvidiaCloudFunctionResourceModel{
+		Id:        types.StringValue(functionID),
+		VersionID: types.StringValue(replaced.Function.VersionID),
+		Tags:      types.SetValueMust(types.StringType, []attr.Value{types.StringValue("tag1")}),
+	}, &deleteDiags)
+	assert.False(t, deleteDiags.HasError(), "deletePreviousVersion() diagnostics = %v", deleteDiags)
+
+	got, err := client.GetNvidiaCloudFunctionVersion(ctx, functionID, replaced.Function.VersionID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tag1", replacedVersionTag}, got.Function.Tags)
+
+	// Another replaced version is still deployed, such as a failed deployment of ngc_cloud_function_deployment.
+	failed, err := client.CreateNvidiaCloudFunction(ctx, functionID, createRequest)
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	_, err = client.UpdateNvidiaCloudFunctionMetadata(ctx, functionID, failed.Function.VersionID, utils.UpdateNvidiaCloudFunctionMetadataRequest{
+		Tags: []string{replacedVersionTag},
+	})
+	if err != nil {
+		t.Fatalf("UpdateNvidiaCloudFunctionMetadata() error = %v", err)
+	}
+	deploy(failed.Function.VersionID)
+	if err := server.SetDeploymentStatus(functionID, failed.Function.VersionID, fakenvcf.StatusError); err != nil {
+		t.Fatalf("SetDeploymentStatus() error = %v", err)
+	}
+
+	rolledOut, err := client.CreateNvidiaCloudFunction(ctx, functionID, createRequest)
+	if err != nil {
+		t.Fatalf("CreateNvidiaCloudFunction() error = %v", err)
+	}
+	deploy(rolledOut.Function.VersionID)
+
+	// plan refreshes the state and plans the unchanged configuration, returning whether the version IDs are unknown.
+	plan := func() (tfsdk.Plan, bool) {
+		t.Helper()
+
+		readResp := resource.ReadResponse{State: state}
+		r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+		assert.False(t, readResp.Diagnostics.HasError(), "Read() diagnostics = %v", readResp.Diagnostics)
+		state = readResp.State
+
+		modifyPlanResp := resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: modifyPlanResp.Plan}, &modifyPlanResp)
+		assert.False(t, modifyPlanResp.Diagnostics.HasError(), "ModifyPlan() diagnostics = %v", modifyPlanResp.Diagnostics)
+
+		var versionIDs types.List
+		modifyPlanResp.Plan.GetAttribute(ctx, path.Root("version_ids"), &versionIDs)
+		return modifyPlanResp.Plan, versionIDs.IsUnknown()
+	}
+
+	// Re-applying the unchanged configuration deletes the replaced version.
+	planned, updated := plan()
+	assert.True(t, updated, "the version beyond retained_versions is not planned to be deleted")
+
+	updateResp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{State: state, Plan: planned}, &updateResp)
+	assert.False(t, updateResp.Diagnostics.HasError(), "Update() diagnostics = %v", updateResp.Diagnostics)
+	state = updateResp.State
+
+	// Only the version left behind without deployment is deleted, the initial version is not a replaced one.
+	assert.Equal(t, []string{initialVersionID, failed.Function.VersionID, rolledOut.Function.VersionID}, server.FunctionVersions(functionID))
+
+	// Nothing is left to delete afterwards.
+	_, updated = plan()
+	assert.False(t, updated, "no version is beyond retained_versions")
+}
@@ -61,13 +61,27 @@ type NvidiaCloudFunctionResourceModelModel struct {
 }
 
 type NvidiaCloudFunctionResourceDeploymentSpecificationModel struct {
-	GpuType               types.String `tfsdk:"gpu_type"`
-	Backend               types.String `tfsdk:"backend"`
-	MaxInstances          types.Int64  `tfsdk:"max_instances"`
-	MinInstances          types.Int64  `tfsdk:"min_instances"`
-	MaxRequestConcurrency types.Int64  `tfsdk:"max_request_concurrency"`
-	Configuration         types.String `tfsdk:"configuration"`
-	InstanceType          types.String `tfsdk:"instance_type"`
+	GpuType               types.String                 `tfsdk:"gpu_type"`
+	Backend               types.String                 `tfsdk:"backend"`
+	MaxInstances          types.Int64                  `tfsdk:"max_instances"`
+	MinInstances          types.Int64                  `tfsdk:"min_instances"`
+	MaxRequestConcurrency types.Int64                  `tfsdk:"max_request_concurrency"`
+	Configuration         DeploymentConfigurationValue `tfsdk:"configuration"`
+	InstanceType          types.String                 `tfsdk:"instance_type"`
+}
+
+type NvidiaCloudFunctionResourceDeploymentModel struct {
+	GpuType               types.String                 `tfsdk:"gpu_type"`
+	MaxInstances          types.Int64                  `tfsdk:"max_instances"`
+	MinInstances          types.Int64                  `tfsdk:"min_instances"`
+	MaxRequestConcurrency types.Int64                  `tfsdk:"max_request_concurrency"`
+	Configuration         DeploymentConfigurationValue `tfsdk:"configuration"`
+	InstanceType          types.String                 `tfsdk:"instance_type"`
+}
+
+type NvidiaCloudFunctionResourceCanaryModel struct {
+	Steps types.List   `tfsdk:"steps"`
+	Pause types.String `tfsdk:"pause"`
 }
 
 type NvidiaCloudFunctionResourceModel struct {
@@ -87,13 +101,19 @@ type NvidiaCloudFunctionResourceModel struct {
 	Health                   types.Object   `tfsdk:"health"`
 	APIBodyFormat            types.String   `tfsdk:"api_body_format"`
 	DeploymentSpecifications types.List     `tfsdk:"deployment_specifications"`
+	Deployments              types.Map      `tfsdk:"deployments"`
 	Tags                     types.Set      `tfsdk:"tags"`
 	Description              types.String   `tfsdk:"description"`
 	Models                   types.Set      `tfsdk:"models"`
 	Resources                types.Set      `tfsdk:"resources"`
 	FunctionType             types.String   `tfsdk:"function_type"`
 	KeepFailedResource       types.Bool     `tfsdk:"keep_failed_resource"`
+	RolloutStrategy          types.String   `tfsdk:"rollout_strategy"`
+	Canary                   types.Object   `tfsdk:"canary"`
 	Timeouts                 timeouts.Value `tfsdk:"timeouts"`
 	Secrets                  types.Set      `tfsdk:"secrets"`
+	SecretsWo                types.Map      `tfsdk:"secrets_wo"`
+	SecretsWoVersion         types.Int64    `tfsdk:"secrets_wo_version"`
 	AuthorizedParties        types.Set      `tfsdk:"authorized_parties"`
+	AuthorizedPartiesScope   types.String   `tfsdk:"authorized_parties_scope"`
 }
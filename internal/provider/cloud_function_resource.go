@@ -14,6 +14,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -24,12 +26,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -43,6 +43,8 @@ const DEFAULT_TIMEOUT_SEC = 60 * 60
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NvidiaCloudFunctionResource{}
 var _ resource.ResourceWithImportState = &NvidiaCloudFunctionResource{}
+var _ resource.ResourceWithValidateConfig = &NvidiaCloudFunctionResource{}
+var _ resource.ResourceWithModifyPlan = &NvidiaCloudFunctionResource{}
 
 func NewNvidiaCloudFunctionResource() resource.Resource {
 	return &NvidiaCloudFunctionResource{}
@@ -68,6 +70,14 @@ func (r *NvidiaCloudFunctionResource) updateNvidiaCloudFunctionResourceModelBase
 		data.KeepFailedResource = types.BoolValue(false)
 	}
 
+	if data.RolloutStrategy.IsNull() || data.RolloutStrategy.IsUnknown() {
+		data.RolloutStrategy = types.StringValue(rolloutStrategyRecreate)
+	}
+
+	if data.AuthorizedPartiesScope.IsNull() || data.AuthorizedPartiesScope.IsUnknown() {
+		data.AuthorizedPartiesScope = types.StringValue(authorizationScopeVersion)
+	}
+
 	if functionInfo.APIBodyFormat != "" {
 		data.APIBodyFormat = types.StringValue(functionInfo.APIBodyFormat)
 	}
@@ -113,27 +123,7 @@ func (r *NvidiaCloudFunctionResource) updateNvidiaCloudFunctionResourceModelBase
 	}
 
 	if functionDeployment != nil && functionDeployment.DeploymentSpecifications != nil {
-		deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0)
-		for _, v := range functionDeployment.DeploymentSpecifications {
-			deploymentSpecification := NvidiaCloudFunctionResourceDeploymentSpecificationModel{
-				Backend:               types.StringValue(v.Backend),
-				InstanceType:          types.StringValue(v.InstanceType),
-				GpuType:               types.StringValue(v.Gpu),
-				MaxInstances:          types.Int64Value(int64(v.MaxInstances)),
-				MinInstances:          types.Int64Value(int64(v.MinInstances)),
-				MaxRequestConcurrency: types.Int64Value(int64(v.MaxRequestConcurrency)),
-			}
-
-			if v.Configuration != nil {
-				configuration, _ := json.Marshal(v.Configuration)
-				deploymentSpecification.Configuration = types.StringValue(string(configuration))
-			}
-
-			deploymentSpecifications = append(deploymentSpecifications, deploymentSpecification)
-		}
-		deploymentSpecificationsListType, deploymentSpecificationsListTypeDiag := types.ListValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), deploymentSpecifications)
-		diag.Append(deploymentSpecificationsListTypeDiag...)
-		data.DeploymentSpecifications = deploymentSpecificationsListType
+		data.setDeploymentSpecifications(ctx, functionDeployment.DeploymentSpecifications, diag)
 	}
 
 	if functionInfo.Tags != nil {
@@ -220,6 +210,7 @@ func (r *NvidiaCloudFunctionResource) updateNvidiaCloudFunctionResourceModelBase
 	data.AuthorizedParties = authorizePartiesSetType
 
 	// We don't update Secret from response, since the secret won't return in response.
+	// The hash of the applied secrets is kept in the private state instead.
 }
 
 func updateTags(
@@ -243,72 +234,102 @@ func updateTags(
 	if err != nil {
 		diag.AddError(
 			"Failed to update function tags",
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
 	}
 }
 
+// updateFunctionAuthorizedParties authorizes the planned parties and unauthorizes the parties of the prior state
+// no longer planned, leaving the parties authorized by others, such as ngc_cloud_function_authorization, untouched.
+// The response only lists the planned parties.
 func updateFunctionAuthorizedParties(
 	ctx context.Context,
-	functionID string,
-	versionID string,
+	target authorizationTarget,
 	authorizePartiesRawData basetypes.SetValue,
+	priorAuthorizePartiesRawData basetypes.SetValue,
 	diag *diag.Diagnostics,
 	client utils.NVCFClient,
 ) utils.AuthorizeAccountsToInvokeFunctionResponse {
-	if !authorizePartiesRawData.IsNull() && len(authorizePartiesRawData.Elements()) > 0 {
-		authorizePartiesInTerraformModel := make([]NvidiaCloudFunctionResourceAuthorizedPartyModel, 0, len(authorizePartiesRawData.Elements()))
-		diag.Append(authorizePartiesRawData.ElementsAs(ctx, &authorizePartiesInTerraformModel, false)...)
+	planned := functionAuthorizedParties(ctx, authorizePartiesRawData, diag)
+	managed := functionAuthorizedParties(ctx, priorAuthorizePartiesRawData, diag)
 
-		if diag.HasError() {
-			return utils.AuthorizeAccountsToInvokeFunctionResponse{}
-		}
+	if diag.HasError() {
+		return utils.AuthorizeAccountsToInvokeFunctionResponse{}
+	}
 
-		authorizeParties := make([]utils.AuthorizedParty, 0)
+	parties, err := updateAuthorizedParties(ctx, &client, target, planned, managed)
 
-		for _, v := range authorizePartiesInTerraformModel {
-			authorizeParties = append(authorizeParties, utils.AuthorizedParty{
-				NcaID: v.NcaID.ValueString(),
-			})
-		}
-		var authorizeAccountsToInvokeFunctionResponse, err = client.AuthorizeAccountsToInvokeFunction(
-			ctx, functionID, versionID,
-			utils.AuthorizeAccountsToInvokeFunctionRequest{
-				AuthorizedParties: authorizeParties,
-			},
+	if err != nil {
+		diag.AddError(
+			"Failed to update the accounts authorized to invoke function",
+			utils.ErrorDetail(err),
 		)
+		return utils.AuthorizeAccountsToInvokeFunctionResponse{}
+	}
 
-		if err != nil {
-			diag.AddError(
-				"Failed to authorize additional accounts to invoke function",
-				err.Error(),
-			)
-			return utils.AuthorizeAccountsToInvokeFunctionResponse{}
-		}
-		return *authorizeAccountsToInvokeFunctionResponse
-	} else {
-		getFunctionAuthorizedPartiesResponse, err := client.GetFunctionAuthorization(ctx, functionID, versionID)
+	return utils.AuthorizeAccountsToInvokeFunctionResponse{
+		Function: utils.AuthorizeAccountsToInvokeFunctionResponseFunctionInfo{
+			Id:                target.functionID,
+			VersionID:         target.versionID,
+			AuthorizedParties: managedAuthorizedParties(parties, planned),
+		},
+	}
+}
 
-		if err != nil {
-			diag.AddError(
-				"Failed to list authorized parties",
-				err.Error(),
-			)
-			return utils.AuthorizeAccountsToInvokeFunctionResponse{}
-		}
-		if len(getFunctionAuthorizedPartiesResponse.Function.AuthorizedParties) > 0 {
-			err = client.UnAuthorizeAllExtraAccountsToInvokeFunction(
-				ctx, functionID, versionID,
-			)
-			if err != nil {
-				diag.AddError(
-					"Failed to unauthorize additional accounts to invoke function",
-					err.Error(),
-				)
-			}
-		}
-		return utils.AuthorizeAccountsToInvokeFunctionResponse{}
+// updateFunctionAuthorizedPartiesScope updates the authorized parties of the function version, or of the function
+// itself with the "function" authorized_parties_scope. The prior state is nil on creation. When the parties move to
+// another scope, they are authorized there before the parties of the prior state are unauthorized, except on
+// the prior version of a rollout which is deleted with its parties, and on the function while versions other than
+// the ones of the resource remain, like on deletion.
+func updateFunctionAuthorizedPartiesScope(
+	ctx context.Context,
+	functionID string,
+	versionID string,
+	plan NvidiaCloudFunctionResourceModel,
+	state *NvidiaCloudFunctionResourceModel,
+	diag *diag.Diagnostics,
+	client utils.NVCFClient,
+) utils.AuthorizeAccountsToInvokeFunctionResponse {
+	plannedTarget := scopedAuthorizationTarget(functionID, plan.AuthorizedPartiesScope, versionID)
+	prior := types.SetNull(authorizedPartiesSchema().NestedObject.Type())
+
+	if state == nil {
+		return updateFunctionAuthorizedParties(ctx, plannedTarget, plan.AuthorizedParties, prior, diag, client)
+	}
+
+	priorTarget := scopedAuthorizationTarget(functionID, state.AuthorizedPartiesScope, state.VersionID.ValueString())
+	if priorTarget == plannedTarget {
+		return updateFunctionAuthorizedParties(ctx, plannedTarget, plan.AuthorizedParties, state.AuthorizedParties, diag, client)
+	}
+
+	authorizedAccounts := updateFunctionAuthorizedParties(ctx, plannedTarget, plan.AuthorizedParties, prior, diag, client)
+
+	if diag.HasError() || (!priorTarget.allVersions && priorTarget.versionID != versionID) {
+		return authorizedAccounts
 	}
+
+	if priorTarget.allVersions && !isLastFunctionVersion(ctx, &client, functionID, []string{versionID, state.VersionID.ValueString()}, diag) {
+		return authorizedAccounts
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("moving the parties authorized to invoke Cloud Function %s to the %s scope", functionID, plan.AuthorizedPartiesScope.ValueString()))
+	updateFunctionAuthorizedParties(ctx, priorTarget, types.SetValueMust(prior.ElementType(ctx), nil), state.AuthorizedParties, diag, client)
+	return authorizedAccounts
+}
+
+// functionAuthorizedParties converts the authorized_parties of ngc_cloud_function to NVCF parties, which authorize
+// any client of their account.
+func functionAuthorizedParties(ctx context.Context, authorizePartiesRawData basetypes.SetValue, diag *diag.Diagnostics) []utils.AuthorizedParty {
+	authorizePartiesInTerraformModel := make([]NvidiaCloudFunctionResourceAuthorizedPartyModel, 0, len(authorizePartiesRawData.Elements()))
+	diag.Append(authorizePartiesRawData.ElementsAs(ctx, &authorizePartiesInTerraformModel, false)...)
+
+	authorizeParties := make([]utils.AuthorizedParty, 0, len(authorizePartiesInTerraformModel))
+	for _, v := range authorizePartiesInTerraformModel {
+		authorizeParties = append(authorizeParties, utils.AuthorizedParty{
+			NcaID: v.NcaID.ValueString(),
+		})
+	}
+	return authorizeParties
 }
 
 func deploymentSpecificationsSchema() schema.ListNestedAttribute {
@@ -316,32 +337,22 @@ func deploymentSpecificationsSchema() schema.ListNestedAttribute {
 		NestedObject: schema.NestedAttributeObject{
 			Attributes: map[string]schema.Attribute{
 				"configuration": schema.StringAttribute{
-					MarkdownDescription: "Will be the json definition to overwrite the existing values.yaml file when deploying Helm-Based Functions",
-					Optional:            true,
-					PlanModifiers: []planmodifier.String{
-						stringplanmodifier.RequiresReplace(),
-					},
+					MarkdownDescription: "Will be the JSON or YAML definition to overwrite the existing values.yaml file when deploying Helm-Based Functions. " +
+						"Configurations with the same content are equal, whatever their format, whitespace or key order.",
+					CustomType: DeploymentConfigurationType{},
+					Optional:   true,
 				},
 				"backend": schema.StringAttribute{
 					MarkdownDescription: "NVCF Backend.",
 					Optional:            true,
-					PlanModifiers: []planmodifier.String{
-						stringplanmodifier.RequiresReplace(),
-					},
 				},
 				"instance_type": schema.StringAttribute{
 					MarkdownDescription: "NVCF Backend Instance Type.",
 					Required:            true,
-					PlanModifiers: []planmodifier.String{
-						stringplanmodifier.RequiresReplace(),
-					},
 				},
 				"gpu_type": schema.StringAttribute{
 					MarkdownDescription: "GPU Type, GFN backend default is L40",
 					Required:            true,
-					PlanModifiers: []planmodifier.String{
-						stringplanmodifier.RequiresReplace(),
-					},
 				},
 				"max_instances": schema.Int64Attribute{
 					MarkdownDescription: "Max Instances Count",
@@ -384,7 +395,7 @@ func resourcesSchema() schema.SetNestedAttribute {
 		},
 		Optional: true,
 		PlanModifiers: []planmodifier.Set{
-			setplanmodifier.RequiresReplace(),
+			setRolloutRequiresReplace(),
 		},
 	}
 }
@@ -409,7 +420,7 @@ func modelsSchema() schema.SetNestedAttribute {
 		},
 		Optional: true,
 		PlanModifiers: []planmodifier.Set{
-			setplanmodifier.RequiresReplace(),
+			setRolloutRequiresReplace(),
 		},
 	}
 }
@@ -430,7 +441,7 @@ func containerEnvironmentsSchema() schema.SetNestedAttribute {
 		},
 		Optional: true,
 		PlanModifiers: []planmodifier.Set{
-			setplanmodifier.RequiresReplace(),
+			setRolloutRequiresReplace(),
 		},
 	}
 }
@@ -442,7 +453,7 @@ func healthSchema() schema.SingleNestedAttribute {
 		// The value will be auto-generated in NVCF API response when user using legacy health_uri field.
 		PlanModifiers: []planmodifier.Object{
 			objectplanmodifier.UseStateForUnknown(),
-			objectplanmodifier.RequiresReplace(),
+			objectRolloutRequiresReplace(),
 		},
 		Attributes: map[string]schema.Attribute{
 			"protocol": schema.StringAttribute{
@@ -484,10 +495,24 @@ func secretsSchema() schema.SetNestedAttribute {
 				},
 			},
 		},
+		MarkdownDescription: "Secrets of the function version. Changing them updates the secrets of the current version in place, " +
+			"NVCF never returns their values so the provider tracks the applied secrets with a hash in the private state.",
 		Optional: true,
 	}
 }
 
+func secretsWriteOnlySchema() schema.MapAttribute {
+	return schema.MapAttribute{
+		MarkdownDescription: "Write-only secrets of the function version, mapping secret names to values that are a string or json node, " +
+			"such as values of ephemeral resources. They are never stored in the state or the plan. " +
+			"Changing them updates nothing unless `secrets_wo_version` changes as well. Conflicts with `secrets`, requires Terraform 1.11 or later.",
+		ElementType: types.StringType,
+		Optional:    true,
+		Sensitive:   true,
+		WriteOnly:   true,
+	}
+}
+
 func authorizedPartiesSchema() schema.SetNestedAttribute {
 	authorizedPartySchema := schema.NestedAttributeObject{
 		Attributes: map[string]schema.Attribute{
@@ -507,6 +532,16 @@ func authorizedPartiesSchema() schema.SetNestedAttribute {
 }
 
 func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	deploymentSpecifications := deploymentSpecificationsSchema()
+	deploymentSpecifications.MarkdownDescription = "Deployment specifications of the function version, one per backend. Conflicts with `deployments`. " +
+		"Adding or removing a backend, or changing its number of instances or its request concurrency, updates the deployment. " +
+		"Changing the instance type, GPU type or configuration of a backend redeploys the function version, keeping its version ID and authorizations. " +
+		"The function version is not available while it is redeployed, its deployment being deleted before the new one is created."
+
+	authorizedParties := authorizedPartiesSchema()
+	authorizedParties.MarkdownDescription = "Associated authorized parties for a specific version of a function, or for the function with the \"function\" `authorized_parties_scope`. " +
+		"Parties are authorized or unauthorized one by one, parties authorized by `ngc_cloud_function_authorization` are left untouched."
+
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Nvidia Cloud Function Resource",
@@ -521,8 +556,9 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				},
 			},
 			"function_id": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Function ID",
+				Optional: true,
+				MarkdownDescription: "Function ID. The resource manages a new version of this function, such as the function of an `ngc_cloud_function_family`, " +
+					"instead of a new function. Only the version is deleted on destroy",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -552,28 +588,28 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				MarkdownDescription: "Helm chart registry uri",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRolloutRequiresReplace(),
 				},
 			},
 			"helm_chart_service_name": schema.StringAttribute{
 				MarkdownDescription: "Target service name",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRolloutRequiresReplace(),
 				},
 			},
 			"inference_port": schema.Int64Attribute{
 				MarkdownDescription: "Target port, will be service port or container port base on function-based",
 				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+					int64RolloutRequiresReplace(),
 				},
 			},
 			"container_image": schema.StringAttribute{
 				MarkdownDescription: "Container image uri",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRolloutRequiresReplace(),
 				},
 			},
 			"container_environment": containerEnvironmentsSchema(),
@@ -581,14 +617,14 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				MarkdownDescription: "Args to be passed when launching the container",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRolloutRequiresReplace(),
 				},
 			},
 			"inference_url": schema.StringAttribute{
 				MarkdownDescription: "Service endpoint Path.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRolloutRequiresReplace(),
 				},
 			},
 			"health_uri": schema.StringAttribute{
@@ -598,7 +634,7 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				DeprecationMessage:  "The parameter is deprecated. Please replace it with `health`",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
+					stringRolloutRequiresReplace(),
 				},
 			},
 			"health":    healthSchema(),
@@ -617,7 +653,7 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
+					stringRolloutRequiresReplace(),
 				},
 			},
 			"function_type": schema.StringAttribute{
@@ -627,7 +663,7 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				Default:             stringdefault.StaticString("DEFAULT"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
+					stringRolloutRequiresReplace(),
 				},
 			},
 			"api_body_format": schema.StringAttribute{
@@ -637,23 +673,54 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				Default:             stringdefault.StaticString("CUSTOM"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
-					stringplanmodifier.RequiresReplace(),
+					stringRolloutRequiresReplace(),
 				},
 			},
-			"deployment_specifications": deploymentSpecificationsSchema(),
+			"deployment_specifications": deploymentSpecifications,
+			"deployments":               deploymentsSchema(),
 			"secrets":                   secretsSchema(),
-			"authorized_parties":        authorizedPartiesSchema(),
+			"secrets_wo":                secretsWriteOnlySchema(),
+			"secrets_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `secrets_wo`, change it to update the secrets of the current version in place with the values of `secrets_wo`.",
+				Optional:            true,
+			},
+			"authorized_parties": authorizedParties,
+			"authorized_parties_scope": schema.StringAttribute{
+				MarkdownDescription: "Scope of `authorized_parties`. With \"version\", the parties are authorized to invoke the function version only, " +
+					"and a blue/green or canary rollout authorizes them again on the new version. " +
+					"With \"function\", the parties are authorized to invoke the function, whatever its version, and keep their access while a rollout replaces the version, " +
+					"the rollout only updating them once the new version is deployed. " +
+					"Deleting the resource, or moving to the \"version\" scope, only unauthorizes them when no other version of the function remains. " +
+					"Default is \"version\"",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(authorizationScopeVersion),
+			},
 			"keep_failed_resource": schema.BoolAttribute{
 				MarkdownDescription: "Don't delete failed resource. Default is \"false\"",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"rollout_strategy": schema.StringAttribute{
+				MarkdownDescription: "How a change to a function version attribute, such as `container_image` or `helm_chart`, is rolled out. " +
+					"With \"recreate\", the resource is replaced and the old version is deleted before the new one is created. " +
+					"With \"blue_green\", a new version of the same function is created and deployed, and the old version is deleted only once the new one is ACTIVE. " +
+					"If the new version fails, the old version is left untouched. " +
+					"With \"canary\", the new version is deployed next to the old one and the capacity is shifted to it in the steps of the `canary` block, rolling back when a step is unhealthy. " +
+					"An old version the rollout could not delete is tagged `terraform-replaced-version`, for `ngc_cloud_function_family` to delete it. Default is \"recreate\"",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(rolloutStrategyRecreate),
+			},
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
 				Update: true,
 			}),
 		},
+		Blocks: map[string]schema.Block{
+			"canary": canarySchema(),
+		},
 	}
 }
 
@@ -715,33 +782,12 @@ func (r *NvidiaCloudFunctionResource) createOrUpdateRequest(ctx context.Context,
 		request.Description = data.Description.ValueString()
 	}
 
-	if !data.Secrets.IsNull() && !data.Secrets.IsUnknown() {
-		secrets := make([]NvidiaCloudFunctionResourceSecretModel, 0)
-		diag.Append(data.Secrets.ElementsAs(ctx, &secrets, false)...)
+	if (!data.Secrets.IsNull() && !data.Secrets.IsUnknown()) || !data.SecretsWo.IsNull() {
+		request.Secrets = data.secrets(ctx, diag)
 
 		if diag.HasError() {
 			return utils.CreateNvidiaCloudFunctionRequest{}
 		}
-
-		for _, v := range secrets {
-			var secretValue interface{}
-			if v.Value.ValueString() != "" {
-				err := json.Unmarshal([]byte(v.Value.ValueString()), &secretValue)
-
-				// When the input is not a valid json, we will put it as string directly.
-				if err != nil {
-					request.Secrets = append(request.Secrets, utils.NvidiaCloudFunctionSecret{
-						Name:  v.Name.ValueString(),
-						Value: v.Value.ValueString(),
-					})
-				} else {
-					request.Secrets = append(request.Secrets, utils.NvidiaCloudFunctionSecret{
-						Name:  v.Name.ValueString(),
-						Value: secretValue,
-					})
-				}
-			}
-		}
 	}
 
 	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
@@ -822,6 +868,7 @@ func (r *NvidiaCloudFunctionResource) Create(ctx context.Context, req resource.C
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	readWriteOnlySecrets(ctx, req.Config, &data, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -852,20 +899,20 @@ func (r *NvidiaCloudFunctionResource) Create(ctx context.Context, req resource.C
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create Cloud Function",
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
 		return
 	}
 
 	function := createNvidiaCloudFunctionResponse.Function
 
-	authorizedAccounts := updateFunctionAuthorizedParties(ctx, function.ID, function.VersionID, data.AuthorizedParties, &resp.Diagnostics, *r.client)
+	authorizedAccounts := updateFunctionAuthorizedPartiesScope(ctx, function.ID, function.VersionID, data, nil, &resp.Diagnostics, *r.client)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if len(data.DeploymentSpecifications.Elements()) == 0 {
+	if !data.hasDeployment() {
 		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &data, &function, nil, &authorizedAccounts)
 	} else {
 		deployment := r.createDeployment(ctx, data, &resp.Diagnostics, function)
@@ -877,6 +924,8 @@ func (r *NvidiaCloudFunctionResource) Create(ctx context.Context, req resource.C
 		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &data, &function, &deployment, &authorizedAccounts)
 	}
 
+	setSecretsHash(ctx, resp.Private, data, &resp.Diagnostics)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -888,7 +937,7 @@ func (r *NvidiaCloudFunctionResource) deleteFailedDeploymentVersion(ctx context.
 		if err != nil {
 			diag.AddError(
 				"Failed to delete failed Cloud Function deployment",
-				err.Error(),
+				utils.ErrorDetail(err),
 			)
 			return
 		}
@@ -909,8 +958,7 @@ func (r *NvidiaCloudFunctionResource) Read(ctx context.Context, req resource.Rea
 	var getFunctionVersionResponse, err = r.client.GetNvidiaCloudFunctionVersion(ctx, data.Id.ValueString(), data.VersionID.ValueString())
 
 	if err != nil {
-		// Check if the error indicates that the resource was not found
-		if strings.Contains(err.Error(), "Not found") {
+		if utils.IsNotFound(err) {
 			// Resource does not exist anymore, remove from state
 			tflog.Warn(ctx, fmt.Sprintf("Cloud Function version %s/%s no longer exists, removing from state", data.Id.ValueString(), data.VersionID.ValueString()))
 			resp.State.RemoveResource(ctx)
@@ -920,49 +968,63 @@ func (r *NvidiaCloudFunctionResource) Read(ctx context.Context, req resource.Rea
 		// For other errors, report them as usual
 		resp.Diagnostics.AddError(
 			"Failed to Get Cloud Function version",
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
 		return
 	}
 
 	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, data.Id.ValueString(), data.VersionID.ValueString())
 
-	if err != nil {
-		// FIXME: extract error messsage to constants.
-		if err.Error() != "failed to find function deployment" {
-			resp.Diagnostics.AddError(
-				"Failed to read Cloud Function deployment",
-				err.Error(),
-			)
-		}
+	if err != nil && !utils.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to read Cloud Function deployment",
+			utils.ErrorDetail(err),
+		)
 	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	authorizedAccounts, err := r.client.GetFunctionAuthorization(ctx, data.Id.ValueString(), data.VersionID.ValueString())
+	authorizedAccounts, err := scopedAuthorizationTarget(data.Id.ValueString(), data.AuthorizedPartiesScope, data.VersionID.ValueString()).get(ctx, r.client)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to get Cloud Function authorization",
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
 		return
 	}
 
-	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &data, &getFunctionVersionResponse.Function, &readNvidiaCloudFunctionDeploymentResponse.Deployment, authorizedAccounts)
+	// Only the parties of the resource are read back, the parties authorized by others are left out.
+	// authorized_parties is null right after import, when every party authorizing any client of its account is read.
+	if !data.AuthorizedParties.IsNull() {
+		authorizedAccounts.Function.AuthorizedParties = managedAuthorizedParties(authorizedAccounts.Function.AuthorizedParties, functionAuthorizedParties(ctx, data.AuthorizedParties, &resp.Diagnostics))
+	} else {
+		authorizedAccounts.Function.AuthorizedParties = slices.DeleteFunc(authorizedAccounts.Function.AuthorizedParties, func(v utils.AuthorizedParty) bool {
+			return v.ClientId != ""
+		})
+	}
+
+	// The deployment is left to ngc_cloud_function_deployment when neither deployment_specifications nor deployments is set.
+	// function_name is only null right after import, when the deployment must be read as well.
+	var deployment *utils.NvidiaCloudFunctionDeployment
+	if !data.DeploymentSpecifications.IsNull() || !data.Deployments.IsNull() || data.FunctionName.IsNull() {
+		deployment = &readNvidiaCloudFunctionDeploymentResponse.Deployment
+	}
+
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &data, &getFunctionVersionResponse.Function, deployment, authorizedAccounts)
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// TODO: Support deployment update, not recreate new function version.
 func (r *NvidiaCloudFunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan, state NvidiaCloudFunctionResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	readWriteOnlySecrets(ctx, req.Config, &plan, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -978,6 +1040,23 @@ func (r *NvidiaCloudFunctionResource) Update(ctx context.Context, req resource.U
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
+	if rollsOutNewVersion(plan.RolloutStrategy) && functionVersionChanged(plan, state) {
+		if plan.RolloutStrategy.ValueString() == rolloutStrategyCanary {
+			r.canaryRollout(ctx, plan, state, resp)
+		} else {
+			r.blueGreenRollout(ctx, plan, state, resp)
+		}
+		return
+	}
+
+	// Secrets are updated first, a failure leaves the function version as it was.
+	r.updateSecrets(ctx, plan, state, req.Private, resp.Private, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	// Update tags if they've changed
 	if !plan.Tags.Equal(state.Tags) {
 		updateTags(ctx, state.Id.ValueString(), state.VersionID.ValueString(), plan.Tags, &resp.Diagnostics, *r.client)
@@ -988,30 +1067,41 @@ func (r *NvidiaCloudFunctionResource) Update(ctx context.Context, req resource.U
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to get Cloud Function",
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
 	}
 
 	function := &getFunctionVersionResponse.Function
 
-	authorizedAccounts := updateFunctionAuthorizedParties(ctx, function.ID, function.VersionID, plan.AuthorizedParties, &resp.Diagnostics, *r.client)
+	authorizedAccounts := updateFunctionAuthorizedPartiesScope(ctx, function.ID, function.VersionID, plan, &state, &resp.Diagnostics, *r.client)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if len(plan.DeploymentSpecifications.Elements()) == 0 {
-		_, err := r.client.DeleteNvidiaCloudFunctionDeployment(ctx, state.Id.ValueString(), state.VersionID.ValueString())
+	if !plan.hasDeployment() {
+		// Only remove a deployment previously managed by this resource, not one owned by ngc_cloud_function_deployment.
+		if state.hasDeployment() {
+			_, err = r.client.DeleteNvidiaCloudFunctionDeployment(ctx, state.Id.ValueString(), state.VersionID.ValueString())
+		}
 		// The case we still save state, since the deployment is disabled and user can delete the version manually.
 		if err != nil {
 			resp.Diagnostics.AddError(
 				fmt.Sprintf("Failed to delete Cloud Function Deployment %s", plan.VersionID.ValueString()),
-				err.Error(),
+				utils.ErrorDetail(err),
 			)
 		}
 		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &plan, function, nil, &authorizedAccounts)
 	} else {
-		deployment := r.updateDeployment(ctx, plan, &resp.Diagnostics)
+		deployment, deployed := r.updateDeployment(ctx, plan, state, &resp.Diagnostics)
+
+		if !deployed {
+			// The state must not keep the deleted deployment, the next apply deploys the function version again.
+			plan.setDeploymentSpecifications(ctx, []utils.NvidiaCloudFunctionDeploymentSpecification{}, &resp.Diagnostics)
+			r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &plan, function, nil, &authorizedAccounts)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
 
 		if resp.Diagnostics.HasError() {
 			return
@@ -1029,17 +1119,68 @@ func (r *NvidiaCloudFunctionResource) Delete(ctx context.Context, req resource.D
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The parties authorized at the function level outlive the version, unless it is the last one of the function.
+	unauthorize := data.AuthorizedPartiesScope.ValueString() == authorizationScopeFunction
+	if unauthorize {
+		unauthorize = isLastFunctionVersion(ctx, r.client, data.Id.ValueString(), []string{data.VersionID.ValueString()}, &resp.Diagnostics)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteNvidiaCloudFunctionVersion(ctx, data.Id.ValueString(), data.VersionID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Failed to delete Cloud Function version %s", data.VersionID.ValueString()),
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
 	}
 
+	if resp.Diagnostics.HasError() || !unauthorize {
+		return
+	}
+
+	managed := functionAuthorizedParties(ctx, data.AuthorizedParties, &resp.Diagnostics)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	_, err = updateAuthorizedParties(ctx, r.client, functionAuthorizationTarget(data.Id.ValueString()), []utils.AuthorizedParty{}, managed)
+	if err != nil && !utils.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to unauthorize Cloud Function parties",
+			utils.ErrorDetail(err),
+		)
+	}
+}
+
+// isLastFunctionVersion tells whether the function has no version other than the given ones of the resource.
+func isLastFunctionVersion(ctx context.Context, client *utils.NVCFClient, functionID string, versionIDs []string, diag *diag.Diagnostics) bool {
+	listNvidiaCloudFunctionVersionsResponse, err := client.ListNvidiaCloudFunctionVersions(ctx, functionID)
+	if err != nil {
+		if utils.IsNotFound(err) {
+			return true
+		}
+		diag.AddError(
+			"Failed to list Cloud Function versions",
+			utils.ErrorDetail(err),
+		)
+		return false
+	}
+
+	for _, versionID := range functionVersionIDs(listNvidiaCloudFunctionVersionsResponse.Functions) {
+		if !slices.Contains(versionIDs, versionID) {
+			tflog.Info(ctx, fmt.Sprintf("keeping the parties authorized on Cloud Function %s, version %s remains", functionID, versionID))
+			return false
+		}
+	}
+	return true
 }
 
 func (r *NvidiaCloudFunctionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -1060,17 +1201,17 @@ func (r *NvidiaCloudFunctionResource) ImportState(ctx context.Context, req resou
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version_id"), idParts[1])...)
 }
 
-func (r *NvidiaCloudFunctionResource) prepareDeploymentSpecifications(
+func prepareDeploymentSpecifications(
 	ctx context.Context,
-	data NvidiaCloudFunctionResourceModel,
+	deploymentSpecificationsRawData basetypes.ListValue,
 	diag *diag.Diagnostics,
 ) []utils.NvidiaCloudFunctionDeploymentSpecification {
-	if data.DeploymentSpecifications.IsNull() || len(data.DeploymentSpecifications.Elements()) == 0 {
+	if deploymentSpecificationsRawData.IsNull() || len(deploymentSpecificationsRawData.Elements()) == 0 {
 		return nil
 	}
 
-	deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(data.DeploymentSpecifications.Elements()))
-	diag.Append(data.DeploymentSpecifications.ElementsAs(ctx, &deploymentSpecifications, false)...)
+	deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(deploymentSpecificationsRawData.Elements()))
+	diag.Append(deploymentSpecificationsRawData.ElementsAs(ctx, &deploymentSpecifications, false)...)
 
 	if diag.HasError() {
 		return nil
@@ -1078,17 +1219,13 @@ func (r *NvidiaCloudFunctionResource) prepareDeploymentSpecifications(
 
 	deploymentSpecificationsOption := make([]utils.NvidiaCloudFunctionDeploymentSpecification, 0)
 	for _, v := range deploymentSpecifications {
-		var configuration interface{}
-		if v.Configuration.ValueString() != "" {
-			err := json.Unmarshal([]byte(v.Configuration.ValueString()), &configuration)
-
-			if err != nil {
-				diag.AddError(
-					"Failed to parse deployment configuration",
-					err.Error(),
-				)
-				return nil
-			}
+		configuration, err := v.Configuration.Unmarshal()
+		if err != nil {
+			diag.AddError(
+				"Failed to parse deployment configuration",
+				err.Error(),
+			)
+			return nil
 		}
 
 		d := utils.NvidiaCloudFunctionDeploymentSpecification{
@@ -1106,10 +1243,38 @@ func (r *NvidiaCloudFunctionResource) prepareDeploymentSpecifications(
 	return deploymentSpecificationsOption
 }
 
+func deploymentSpecificationsListValue(
+	ctx context.Context,
+	deploymentSpecificationsResponse []utils.NvidiaCloudFunctionDeploymentSpecification,
+	diag *diag.Diagnostics,
+) basetypes.ListValue {
+	deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0)
+	for _, v := range deploymentSpecificationsResponse {
+		deploymentSpecification := NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+			Backend:               types.StringValue(v.Backend),
+			InstanceType:          types.StringValue(v.InstanceType),
+			GpuType:               types.StringValue(v.Gpu),
+			MaxInstances:          types.Int64Value(int64(v.MaxInstances)),
+			MinInstances:          types.Int64Value(int64(v.MinInstances)),
+			MaxRequestConcurrency: types.Int64Value(int64(v.MaxRequestConcurrency)),
+		}
+
+		if v.Configuration != nil {
+			configuration, _ := json.Marshal(v.Configuration)
+			deploymentSpecification.Configuration = NewDeploymentConfigurationValue(string(configuration))
+		}
+
+		deploymentSpecifications = append(deploymentSpecifications, deploymentSpecification)
+	}
+	deploymentSpecificationsListType, deploymentSpecificationsListTypeDiag := types.ListValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), deploymentSpecifications)
+	diag.Append(deploymentSpecificationsListTypeDiag...)
+	return deploymentSpecificationsListType
+}
+
 func (r *NvidiaCloudFunctionResource) createDeployment(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics, function utils.NvidiaCloudFunctionInfo) utils.NvidiaCloudFunctionDeployment {
 	var functionDeployment utils.NvidiaCloudFunctionDeployment
 
-	deploymentSpecificationsOption := r.prepareDeploymentSpecifications(ctx, data, diag)
+	deploymentSpecificationsOption := data.deploymentSpecifications(ctx, diag)
 	if diag.HasError() || deploymentSpecificationsOption == nil {
 		return functionDeployment
 	}
@@ -1124,7 +1289,7 @@ func (r *NvidiaCloudFunctionResource) createDeployment(ctx context.Context, data
 	if err != nil {
 		diag.AddError(
 			"Failed to create Cloud Function Deployment",
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
 		return functionDeployment
 	}
@@ -1133,7 +1298,7 @@ func (r *NvidiaCloudFunctionResource) createDeployment(ctx context.Context, data
 	if err != nil {
 		diag.AddError(
 			"Failed to create Cloud Function Deployment",
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
 		return functionDeployment
 	}
@@ -1141,12 +1306,84 @@ func (r *NvidiaCloudFunctionResource) createDeployment(ctx context.Context, data
 	return createNvidiaCloudFunctionDeploymentResponse.Deployment
 }
 
-func (r *NvidiaCloudFunctionResource) updateDeployment(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) utils.NvidiaCloudFunctionDeployment {
+// deploymentRedeployRequired reports whether the deployment must be recreated to apply the planned specifications,
+// because a backend gets another instance type, GPU or configuration, or another number of specifications.
+// The specifications are matched by backend, so reordering them, adding or removing a backend, or changing
+// the number of instances or the request concurrency is applied to the existing deployment.
+func deploymentRedeployRequired(planned []utils.NvidiaCloudFunctionDeploymentSpecification, current []utils.NvidiaCloudFunctionDeploymentSpecification) bool {
+	currentByBackend := make(map[string][]utils.NvidiaCloudFunctionDeploymentSpecification)
+	for _, v := range current {
+		currentByBackend[v.Backend] = append(currentByBackend[v.Backend], v)
+	}
+
+	plannedByBackend := make(map[string][]utils.NvidiaCloudFunctionDeploymentSpecification)
+	for _, v := range planned {
+		plannedByBackend[v.Backend] = append(plannedByBackend[v.Backend], v)
+	}
+
+	for backend, plannedSpecifications := range plannedByBackend {
+		currentSpecifications, ok := currentByBackend[backend]
+		if !ok {
+			continue
+		}
+
+		if len(plannedSpecifications) != len(currentSpecifications) {
+			return true
+		}
+
+		for i := range plannedSpecifications {
+			if plannedSpecifications[i].InstanceType != currentSpecifications[i].InstanceType ||
+				plannedSpecifications[i].Gpu != currentSpecifications[i].Gpu ||
+				!reflect.DeepEqual(plannedSpecifications[i].Configuration, currentSpecifications[i].Configuration) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// updateDeployment applies the planned deployment specifications to the deployment of the function version.
+// It reports false when the function version is left without deployment, the previous deployment being
+// deleted to be recreated while the new one could not be created.
+func (r *NvidiaCloudFunctionResource) updateDeployment(ctx context.Context, data NvidiaCloudFunctionResourceModel, state NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) (utils.NvidiaCloudFunctionDeployment, bool) {
 	var functionDeployment utils.NvidiaCloudFunctionDeployment
 
-	deploymentSpecificationsOption := r.prepareDeploymentSpecifications(ctx, data, diag)
+	deploymentSpecificationsOption := data.deploymentSpecifications(ctx, diag)
+	currentDeploymentSpecifications := state.deploymentSpecifications(ctx, diag)
 	if diag.HasError() || deploymentSpecificationsOption == nil {
-		return functionDeployment
+		return functionDeployment, true
+	}
+
+	// The function version has no deployment to update after a failed redeployment.
+	if !state.hasDeployment() {
+		return r.deployFunctionVersion(ctx, data, deploymentSpecificationsOption, diag)
+	}
+
+	// The function version and its authorizations are kept, only the deployment is recreated.
+	if deploymentRedeployRequired(deploymentSpecificationsOption, currentDeploymentSpecifications) {
+		tflog.Info(ctx, fmt.Sprintf("redeploying Cloud Function version %s with the updated deployment specifications", data.VersionID.ValueString()))
+
+		_, err := r.client.DeleteNvidiaCloudFunctionDeployment(ctx, data.Id.ValueString(), data.VersionID.ValueString())
+		if err != nil && !utils.IsNotFound(err) {
+			diag.AddError(
+				"Failed to update Cloud Function Deployment",
+				utils.ErrorDetail(err),
+			)
+			return functionDeployment, true
+		}
+
+		// Creating the deployment while the previous one is torn down would conflict with it.
+		err = r.client.WaitingDeploymentDeleted(ctx, data.Id.ValueString(), data.VersionID.ValueString())
+		if err != nil {
+			diag.AddError(
+				"Failed to update Cloud Function Deployment",
+				fmt.Sprintf("The deployment of Cloud Function version %s was deleted to be recreated, the function version is left undeployed: %s",
+					data.VersionID.ValueString(), utils.ErrorDetail(err)),
+			)
+			return functionDeployment, false
+		}
+
+		return r.deployFunctionVersion(ctx, data, deploymentSpecificationsOption, diag)
 	}
 
 	updateNvidiaCloudFunctionDeploymentResponse, err := r.client.UpdateNvidiaCloudFunctionDeployment(
@@ -1159,19 +1396,55 @@ func (r *NvidiaCloudFunctionResource) updateDeployment(ctx context.Context, data
 	if err != nil {
 		diag.AddError(
 			"Failed to update Cloud Function Deployment",
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
-		return functionDeployment
+		return functionDeployment, true
+	}
+
+	// A scaled deployment stays ACTIVE, wait for its instances instead of its status.
+	err = r.client.WaitingDeploymentScaled(ctx, data.Id.ValueString(), data.VersionID.ValueString(), deploymentSpecificationsOption)
+	if err != nil {
+		diag.AddError(
+			"Failed to update Cloud Function Deployment",
+			utils.ErrorDetail(err),
+		)
+		return functionDeployment, true
+	}
+
+	return updateNvidiaCloudFunctionDeploymentResponse.Deployment, true
+}
+
+// deployFunctionVersion creates the deployment of a function version without one. It reports false when
+// the deployment could not be created, the function version being left undeployed.
+func (r *NvidiaCloudFunctionResource) deployFunctionVersion(
+	ctx context.Context,
+	data NvidiaCloudFunctionResourceModel,
+	deploymentSpecifications []utils.NvidiaCloudFunctionDeploymentSpecification,
+	diag *diag.Diagnostics,
+) (utils.NvidiaCloudFunctionDeployment, bool) {
+	createNvidiaCloudFunctionDeploymentResponse, err := r.client.CreateNvidiaCloudFunctionDeployment(
+		ctx, data.Id.ValueString(), data.VersionID.ValueString(),
+		utils.CreateNvidiaCloudFunctionDeploymentRequest{
+			DeploymentSpecifications: deploymentSpecifications,
+		},
+	)
+
+	if err != nil {
+		diag.AddError(
+			"Failed to update Cloud Function Deployment",
+			fmt.Sprintf("The deployment of Cloud Function version %s could not be created, the function version is left undeployed: %s",
+				data.VersionID.ValueString(), utils.ErrorDetail(err)),
+		)
+		return utils.NvidiaCloudFunctionDeployment{}, false
 	}
 
 	err = r.client.WaitingDeploymentCompleted(ctx, data.Id.ValueString(), data.VersionID.ValueString())
 	if err != nil {
 		diag.AddError(
 			"Failed to update Cloud Function Deployment",
-			err.Error(),
+			utils.ErrorDetail(err),
 		)
-		return functionDeployment
 	}
 
-	return updateNvidiaCloudFunctionDeploymentResponse.Deployment
+	return createNvidiaCloudFunctionDeploymentResponse.Deployment, true
 }
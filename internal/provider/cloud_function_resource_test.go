@@ -15,13 +15,19 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/fakenvcf"
 	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/testutils"
 )
 
@@ -39,11 +45,30 @@ func generateStateResourceId(resourceName string) resource.ImportStateIdFunc {
 	}
 }
 
+// testCheckOnlyFunctionVersion verifies the function only has the given version left, with the given status.
+func testCheckOnlyFunctionVersion(functionID *string, versionID *string, status string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		versions, err := testutils.TestNVCFClient.ListNvidiaCloudFunctionVersions(testutils.Ctx, *functionID)
+		if err != nil {
+			return err
+		}
+		if len(versions.Functions) != 1 || versions.Functions[0].VersionID != *versionID {
+			return fmt.Errorf("expected function %s to only have version %s, got %+v", *functionID, *versionID, versions.Functions)
+		}
+		if versions.Functions[0].Status != status {
+			return fmt.Errorf("expected function version %s to be %s, got %s", *versionID, status, versions.Functions[0].Status)
+		}
+		return nil
+	}
+}
+
 func TestAccCloudFunctionResource_HelmBasedFunction(t *testing.T) {
 	var functionName = uuid.New().String()
 	var testCloudFunctionResourceName = fmt.Sprintf("terraform-cloud-function-integ-resource-%s", functionName)
 	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", testCloudFunctionResourceName)
 
+	var versionID string
+
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -287,6 +312,10 @@ func TestAccCloudFunctionResource_HelmBasedFunction(t *testing.T) {
 					testutils.TestGpuType,
 				),
 				Check: resource.ComposeAggregateTestCheckFunc(
+					func(state *terraform.State) error {
+						versionID = state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes["version_id"]
+						return nil
+					},
 					resource.TestCheckResourceAttrSet(testCloudFunctionResourceFullPath, "id"),
 					resource.TestCheckResourceAttrSet(testCloudFunctionResourceFullPath, "version_id"),
 
@@ -319,7 +348,7 @@ func TestAccCloudFunctionResource_HelmBasedFunction(t *testing.T) {
 					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "authorized_parties.#", "0"),
 				),
 			},
-			// Verify Function Force-Replace Update with Creation Timeout
+			// Verify Deployment In-place Update with Update Timeout
 			{
 				Config: fmt.Sprintf(`
 									resource "ngc_cloud_function" "%s" {
@@ -348,7 +377,7 @@ func TestAccCloudFunctionResource_HelmBasedFunction(t *testing.T) {
 											}
 										]
 										timeouts = {
-											create = "1s"
+											update = "1s"
 										}
 									}
 									`,
@@ -368,7 +397,7 @@ func TestAccCloudFunctionResource_HelmBasedFunction(t *testing.T) {
 				),
 				ExpectError: regexp.MustCompile("timeout occurred"),
 			},
-			// Verify Function Force-Replace Update
+			// Verify Deployment In-place Update keeps the function version
 			{
 				Config: fmt.Sprintf(`
 									resource "ngc_cloud_function" "%s" {
@@ -414,7 +443,7 @@ func TestAccCloudFunctionResource_HelmBasedFunction(t *testing.T) {
 				),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet(testCloudFunctionResourceFullPath, "id"),
-					resource.TestCheckResourceAttrSet(testCloudFunctionResourceFullPath, "version_id"),
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "version_id", &versionID),
 
 					resource.TestCheckNoResourceAttr(testCloudFunctionResourceFullPath, "function_id"),
 					resource.TestCheckNoResourceAttr(testCloudFunctionResourceFullPath, "container_image"),
@@ -1277,3 +1306,934 @@ func TestAccCloudFunctionResource_FunctionWithoutDeployment(t *testing.T) {
 		},
 	})
 }
+
+func TestAccCloudFunctionResource_BlueGreenRollout(t *testing.T) {
+	var functionName = uuid.New().String()
+	var testCloudFunctionResourceName = fmt.Sprintf("terraform-cloud-function-integ-resource-%s", functionName)
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", testCloudFunctionResourceName)
+
+	var blueFunctionID, blueVersionID string
+
+	config := func(description string, updateTimeout string) string {
+		return fmt.Sprintf(`
+				resource "ngc_cloud_function" "%s" {
+					function_name           = "%s"
+					container_image         = "%s"
+					inference_port          = %d
+					inference_url           = "%s"
+					health                    = {
+						uri                  = "%s"
+						port                 = %d
+						expected_status_code = 200
+						timeout              = "PT10S"
+						protocol             = "HTTP"
+					}
+					api_body_format         = "%s"
+					description             = "%s"
+					rollout_strategy        = "blue_green"
+					deployment_specifications = [
+						{
+							backend                 = "%s"
+							instance_type           = "%s"
+							gpu_type                = "%s"
+							max_instances           = 1
+							min_instances           = 1
+							max_request_concurrency = 1
+						}
+					]
+					timeouts = {
+						update = "%s"
+					}
+				}
+				`,
+			testCloudFunctionResourceName,
+			functionName,
+			testutils.TestContainerUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerInferenceUrl,
+			testutils.TestContainerHealthUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerAPIFormat,
+			description,
+			testutils.TestBackend,
+			testutils.TestInstanceType,
+			testutils.TestGpuType,
+			updateTimeout,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify Function Creation
+			{
+				Config: config("blue", "1h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "rollout_strategy", "blue_green"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "description", "blue"),
+					func(state *terraform.State) error {
+						attributes := state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes
+						blueFunctionID, blueVersionID = attributes["id"], attributes["version_id"]
+						return nil
+					},
+				),
+			},
+			// Verify the failed rollout keeps the old version
+			{
+				Config:      config("green", "1s"),
+				ExpectError: regexp.MustCompile("timeout occurred"),
+			},
+			{
+				Config: config("blue", "1h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "id", &blueFunctionID),
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "version_id", &blueVersionID),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "description", "blue"),
+					testCheckOnlyFunctionVersion(&blueFunctionID, &blueVersionID, "ACTIVE"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Verify the rollout replaces the old version in place
+			{
+				Config: config("green", "1h"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(testCloudFunctionResourceFullPath, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(testCloudFunctionResourceFullPath, tfjsonpath.New("version_id")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "id", &blueFunctionID),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "description", "green"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications.#", "1"),
+					func(state *terraform.State) error {
+						greenVersionID := state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes["version_id"]
+						if greenVersionID == blueVersionID {
+							return fmt.Errorf("expected a new function version, got %s", greenVersionID)
+						}
+						return testCheckOnlyFunctionVersion(&blueFunctionID, &greenVersionID, "ACTIVE")(state)
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionResource_CanaryRollout(t *testing.T) {
+	var functionName = uuid.New().String()
+	var testCloudFunctionResourceName = fmt.Sprintf("terraform-cloud-function-integ-resource-%s", functionName)
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", testCloudFunctionResourceName)
+
+	var functionID, oldVersionID string
+
+	config := func(description string, updateTimeout string) string {
+		return fmt.Sprintf(`
+				resource "ngc_cloud_function" "%s" {
+					function_name           = "%s"
+					container_image         = "%s"
+					inference_port          = %d
+					inference_url           = "%s"
+					health                    = {
+						uri                  = "%s"
+						port                 = %d
+						expected_status_code = 200
+						timeout              = "PT10S"
+						protocol             = "HTTP"
+					}
+					api_body_format         = "%s"
+					description             = "%s"
+					rollout_strategy        = "canary"
+					canary {
+						steps = [50]
+						pause = "1s"
+					}
+					deployment_specifications = [
+						{
+							backend                 = "%s"
+							instance_type           = "%s"
+							gpu_type                = "%s"
+							max_instances           = 2
+							min_instances           = 1
+							max_request_concurrency = 1
+						}
+					]
+					timeouts = {
+						update = "%s"
+					}
+				}
+				`,
+			testCloudFunctionResourceName,
+			functionName,
+			testutils.TestContainerUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerInferenceUrl,
+			testutils.TestContainerHealthUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerAPIFormat,
+			description,
+			testutils.TestBackend,
+			testutils.TestInstanceType,
+			testutils.TestGpuType,
+			updateTimeout,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify Function Creation
+			{
+				Config: config("blue", "1h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "rollout_strategy", "canary"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "canary.steps.#", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "canary.pause", "1s"),
+					func(state *terraform.State) error {
+						attributes := state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes
+						functionID, oldVersionID = attributes["id"], attributes["version_id"]
+						return nil
+					},
+				),
+			},
+			// Verify the failed rollout is rolled back
+			{
+				Config:      config("green", "1s"),
+				ExpectError: regexp.MustCompile("Canary rollout failed at step 1"),
+			},
+			{
+				Config: config("blue", "1h"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "version_id", &oldVersionID),
+					testCheckOnlyFunctionVersion(&functionID, &oldVersionID, "ACTIVE"),
+				),
+			},
+			// Verify the rollout shifts the whole capacity to the new version
+			{
+				Config: config("green", "1h"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(testCloudFunctionResourceFullPath, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(testCloudFunctionResourceFullPath, tfjsonpath.New("version_id")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "id", &functionID),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "description", "green"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications.0.max_instances", "2"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications.0.min_instances", "1"),
+					func(state *terraform.State) error {
+						newVersionID := state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes["version_id"]
+						if newVersionID == oldVersionID {
+							return fmt.Errorf("expected a new function version, got %s", newVersionID)
+						}
+						deployment, err := testutils.TestNVCFClient.ReadNvidiaCloudFunctionDeployment(testutils.Ctx, functionID, newVersionID)
+						if err != nil {
+							return err
+						}
+						if maxInstances := deployment.Deployment.DeploymentSpecifications[0].MaxInstances; maxInstances != 2 {
+							return fmt.Errorf("expected the new version to have the whole capacity of 2 instances, got %d", maxInstances)
+						}
+						return testCheckOnlyFunctionVersion(&functionID, &newVersionID, "ACTIVE")(state)
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionResource_Deployments(t *testing.T) {
+	var functionName = uuid.New().String()
+	var testCloudFunctionResourceName = fmt.Sprintf("terraform-cloud-function-integ-resource-%s", functionName)
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", testCloudFunctionResourceName)
+
+	var functionID, versionID string
+
+	config := func(deployments string) string {
+		return fmt.Sprintf(`
+				resource "ngc_cloud_function" "%s" {
+					function_name           = "%s"
+					container_image         = "%s"
+					inference_port          = %d
+					inference_url           = "%s"
+					health                    = {
+						uri                  = "%s"
+						port                 = %d
+						expected_status_code = 200
+						timeout              = "PT10S"
+						protocol             = "HTTP"
+					}
+					api_body_format         = "%s"
+					%s
+				}
+				`,
+			testCloudFunctionResourceName,
+			functionName,
+			testutils.TestContainerUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerInferenceUrl,
+			testutils.TestContainerHealthUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerAPIFormat,
+			deployments,
+		)
+	}
+
+	deployments := func(maxInstances int) string {
+		return fmt.Sprintf(`
+					deployments = {
+						"%s" = {
+							instance_type           = "%s"
+							gpu_type                = "%s"
+							max_instances           = %d
+							min_instances           = 1
+							max_request_concurrency = 1
+						}
+					}`,
+			testutils.TestBackend,
+			testutils.TestInstanceType,
+			testutils.TestGpuType,
+			maxInstances,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify deployments conflicts with deployment_specifications
+			{
+				Config: config(deployments(1) + fmt.Sprintf(`
+					deployment_specifications = [
+						{
+							backend                 = "%s"
+							instance_type           = "%s"
+							gpu_type                = "%s"
+							max_instances           = 1
+							min_instances           = 1
+							max_request_concurrency = 1
+						}
+					]`,
+					testutils.TestBackend,
+					testutils.TestInstanceType,
+					testutils.TestGpuType,
+				)),
+				ExpectError: regexp.MustCompile("Conflicting deployments Configuration"),
+			},
+			// Verify Function Creation
+			{
+				Config: config(deployments(1)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployments.%", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, fmt.Sprintf("deployments.%s.instance_type", testutils.TestBackend), testutils.TestInstanceType),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, fmt.Sprintf("deployments.%s.gpu_type", testutils.TestBackend), testutils.TestGpuType),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, fmt.Sprintf("deployments.%s.max_instances", testutils.TestBackend), "1"),
+					resource.TestCheckNoResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications"),
+					func(state *terraform.State) error {
+						attributes := state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes
+						functionID, versionID = attributes["id"], attributes["version_id"]
+						return nil
+					},
+				),
+			},
+			// Verify the backend is scaled in place
+			{
+				Config: config(deployments(2)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(testCloudFunctionResourceFullPath, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "version_id", &versionID),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, fmt.Sprintf("deployments.%s.max_instances", testutils.TestBackend), "2"),
+					func(state *terraform.State) error {
+						deployment, err := testutils.TestNVCFClient.ReadNvidiaCloudFunctionDeployment(testutils.Ctx, functionID, versionID)
+						if err != nil {
+							return err
+						}
+						if maxInstances := deployment.Deployment.DeploymentSpecifications[0].MaxInstances; maxInstances != 2 {
+							return fmt.Errorf("expected the deployment to be scaled to 2 instances, got %d", maxInstances)
+						}
+						return nil
+					},
+				),
+			},
+			// Verify the deployment is removed with deployments
+			{
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "version_id", &versionID),
+					resource.TestCheckNoResourceAttr(testCloudFunctionResourceFullPath, "deployments.%"),
+					testCheckOnlyFunctionVersion(&functionID, &versionID, "INACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionResource_YAMLDeploymentConfiguration(t *testing.T) {
+	var functionName = uuid.New().String()
+	var testCloudFunctionResourceName = fmt.Sprintf("terraform-cloud-function-integ-resource-%s", functionName)
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", testCloudFunctionResourceName)
+
+	config := func(configuration string) string {
+		return fmt.Sprintf(`
+				resource "ngc_cloud_function" "%s" {
+					function_name           = "%s"
+					helm_chart              = "%s"
+					helm_chart_service_name = "%s"
+					inference_port          = %d
+					inference_url           = "%s"
+					health                  = {
+						uri                  = "%s"
+						port                 = %d
+						expected_status_code = 200
+						timeout              = "PT10S"
+						protocol             = "HTTP"
+					}
+					api_body_format         = "%s"
+					deployment_specifications = [
+						{
+							configuration           = %s
+							backend                 = "%s"
+							instance_type           = "%s"
+							gpu_type                = "%s"
+							max_instances           = 1
+							min_instances           = 1
+							max_request_concurrency = 1
+						}
+					]
+				}
+				`,
+			testCloudFunctionResourceName,
+			functionName,
+			testutils.TestHelmUri,
+			testutils.TestHelmServiceName,
+			testutils.TestHelmServicePort,
+			testutils.TestHelmInferenceUrl,
+			testutils.TestHelmHealthUri,
+			testutils.TestHelmServicePort,
+			testutils.TestHelmAPIFormat,
+			configuration,
+			testutils.TestBackend,
+			testutils.TestInstanceType,
+			testutils.TestGpuType,
+		)
+	}
+
+	yamlConfiguration := fmt.Sprintf(`yamlencode(jsondecode("%s"))`, testutils.EscapeJSON(t, testutils.TestHelmValueOverWrite))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify the configuration is validated at plan time
+			{
+				Config:      config(`"image: [nvcr.io"`),
+				ExpectError: regexp.MustCompile("Invalid deployment configuration"),
+			},
+			// Verify Function Creation with a YAML configuration, which is read back from NVCF as JSON without a diff
+			{
+				Config: config(yamlConfiguration),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith(testCloudFunctionResourceFullPath, "deployment_specifications.0.configuration", func(value string) error {
+						if !strings.HasPrefix(value, "\"image\":") {
+							return fmt.Errorf("expected the YAML configuration to be kept in state, got %q", value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				RefreshState: true,
+			},
+			{
+				Config: config(yamlConfiguration),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionResource_Redeployment(t *testing.T) {
+	var functionName = uuid.New().String()
+	var testCloudFunctionResourceName = fmt.Sprintf("terraform-cloud-function-integ-resource-%s", functionName)
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", testCloudFunctionResourceName)
+
+	var functionID, versionID string
+
+	config := func(configuration string) string {
+		return fmt.Sprintf(`
+				resource "ngc_cloud_function" "%s" {
+					function_name           = "%s"
+					helm_chart              = "%s"
+					helm_chart_service_name = "%s"
+					inference_port          = %d
+					inference_url           = "%s"
+					health                  = {
+						uri                  = "%s"
+						port                 = %d
+						expected_status_code = 200
+						timeout              = "PT10S"
+						protocol             = "HTTP"
+					}
+					api_body_format         = "%s"
+					deployment_specifications = [
+						{
+							configuration           = "%s"
+							backend                 = "%s"
+							instance_type           = "%s"
+							gpu_type                = "%s"
+							max_instances           = 1
+							min_instances           = 1
+							max_request_concurrency = 1
+						}
+					]
+				}
+				`,
+			testCloudFunctionResourceName,
+			functionName,
+			testutils.TestHelmUri,
+			testutils.TestHelmServiceName,
+			testutils.TestHelmServicePort,
+			testutils.TestHelmInferenceUrl,
+			testutils.TestHelmHealthUri,
+			testutils.TestHelmServicePort,
+			testutils.TestHelmAPIFormat,
+			testutils.EscapeJSON(t, configuration),
+			testutils.TestBackend,
+			testutils.TestInstanceType,
+			testutils.TestGpuType,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify Function Creation
+			{
+				Config: config(testutils.TestHelmValueOverWrite),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(state *terraform.State) error {
+						attributes := state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes
+						functionID, versionID = attributes["id"], attributes["version_id"]
+						return nil
+					},
+				),
+			},
+			// Verify a redeployment failing once the previous deployment is deleted leaves the function version undeployed
+			{
+				SkipFunc: func() (bool, error) { return testutils.FakeNVCF == nil, nil },
+				PreConfig: func() {
+					testutils.FakeNVCF.InjectFault(fakenvcf.Fault{
+						Method:     http.MethodPost,
+						Path:       "/nvcf/deployments/functions/" + functionID + "/versions/" + versionID,
+						StatusCode: http.StatusBadRequest,
+						Times:      1,
+					})
+				},
+				Config:      config(testutils.TestHelmValueOverWriteUpdated),
+				ExpectError: regexp.MustCompile("left undeployed"),
+			},
+			// Verify the function version is redeployed, whether its previous deployment is left or not
+			{
+				Config: config(testutils.TestHelmValueOverWriteUpdated),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(testCloudFunctionResourceFullPath, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "version_id", &versionID),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications.#", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications.0.configuration", testutils.TestHelmValueOverWriteUpdated),
+					func(state *terraform.State) error {
+						deployment, err := testutils.TestNVCFClient.ReadNvidiaCloudFunctionDeployment(testutils.Ctx, functionID, versionID)
+						if err != nil {
+							return err
+						}
+						if status := deployment.Deployment.FunctionStatus; status != "ACTIVE" {
+							return fmt.Errorf("expected the function version to be redeployed, got status %q", status)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config(testutils.TestHelmValueOverWriteUpdated),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionResource_SecretsUpdate(t *testing.T) {
+	var functionName = uuid.New().String()
+	var testCloudFunctionResourceName = fmt.Sprintf("terraform-cloud-function-integ-resource-%s", functionName)
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", testCloudFunctionResourceName)
+
+	var functionID, versionID string
+
+	config := func(password string) string {
+		return fmt.Sprintf(`
+				resource "ngc_cloud_function" "%s" {
+					function_name           = "%s"
+					container_image         = "%s"
+					inference_port          = %d
+					inference_url           = "%s"
+					health                    = {
+						uri                  = "%s"
+						port                 = %d
+						expected_status_code = 200
+						timeout              = "PT10S"
+						protocol             = "HTTP"
+					}
+					api_body_format         = "%s"
+					secrets = [
+						{
+							name  = "DB_PASSWORD"
+							value = "%s"
+						}
+					]
+				}
+				`,
+			testCloudFunctionResourceName,
+			functionName,
+			testutils.TestContainerUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerInferenceUrl,
+			testutils.TestContainerHealthUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerAPIFormat,
+			password,
+		)
+	}
+
+	testCheckFakeSecret := func(password string) resource.TestCheckFunc {
+		return func(state *terraform.State) error {
+			if testutils.FakeNVCF == nil {
+				return nil
+			}
+			if secrets := testutils.FakeNVCF.FunctionSecrets(functionID, versionID); secrets["DB_PASSWORD"] != password {
+				return fmt.Errorf("expected DB_PASSWORD to be %q, got %v", password, secrets["DB_PASSWORD"])
+			}
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify Function Creation
+			{
+				Config: config("initial"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "secrets.#", "1"),
+					func(state *terraform.State) error {
+						attributes := state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes
+						functionID, versionID = attributes["id"], attributes["version_id"]
+						return nil
+					},
+					testCheckFakeSecret("initial"),
+				),
+			},
+			// Verify a failed secret update keeps the previous secrets
+			{
+				SkipFunc: func() (bool, error) { return testutils.FakeNVCF == nil, nil },
+				PreConfig: func() {
+					testutils.FakeNVCF.InjectFault(fakenvcf.Fault{
+						Method:     http.MethodPut,
+						Path:       "/nvcf/secrets/functions/" + functionID + "/versions/" + versionID,
+						StatusCode: http.StatusBadRequest,
+						Times:      1,
+					})
+				},
+				Config:      config("rotated"),
+				ExpectError: regexp.MustCompile("Failed to update Cloud Function secrets"),
+			},
+			// Verify the secrets are rotated in place
+			{
+				Config: config("rotated"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(testCloudFunctionResourceFullPath, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "version_id", &versionID),
+					testCheckFakeSecret("rotated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionResource_WriteOnlySecrets(t *testing.T) {
+	var functionName = uuid.New().String()
+	var testCloudFunctionResourceName = fmt.Sprintf("terraform-cloud-function-integ-resource-%s", functionName)
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", testCloudFunctionResourceName)
+
+	var functionID, versionID string
+
+	config := func(secrets string, password string, secretsVersion int) string {
+		return fmt.Sprintf(`
+				ephemeral "ngc_cloud_function_secret" "password" {
+					name  = "DB_PASSWORD"
+					value = "%s"
+				}
+
+				ephemeral "ngc_cloud_function_secret" "credentials" {
+					name   = "DB_CREDENTIALS"
+					values = {
+						username = "admin"
+						password = "%s"
+					}
+				}
+
+				resource "ngc_cloud_function" "%s" {
+					function_name           = "%s"
+					container_image         = "%s"
+					inference_port          = %d
+					inference_url           = "%s"
+					health                    = {
+						uri                  = "%s"
+						port                 = %d
+						expected_status_code = 200
+						timeout              = "PT10S"
+						protocol             = "HTTP"
+					}
+					api_body_format         = "%s"
+					%s
+					secrets_wo              = merge(ephemeral.ngc_cloud_function_secret.password.secret, ephemeral.ngc_cloud_function_secret.credentials.secret)
+					secrets_wo_version      = %d
+				}
+				`,
+			password,
+			password,
+			testCloudFunctionResourceName,
+			functionName,
+			testutils.TestContainerUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerInferenceUrl,
+			testutils.TestContainerHealthUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerAPIFormat,
+			secrets,
+			secretsVersion,
+		)
+	}
+
+	testCheckFakeSecret := func(password string) resource.TestCheckFunc {
+		return func(state *terraform.State) error {
+			if testutils.FakeNVCF == nil {
+				return nil
+			}
+			secrets := testutils.FakeNVCF.FunctionSecrets(functionID, versionID)
+			if secrets["DB_PASSWORD"] != password {
+				return fmt.Errorf("expected DB_PASSWORD to be %q, got %v", password, secrets["DB_PASSWORD"])
+			}
+			if credentials, ok := secrets["DB_CREDENTIALS"].(map[string]interface{}); !ok || credentials["password"] != password {
+				return fmt.Errorf("expected DB_CREDENTIALS to be a json node with password %q, got %v", password, secrets["DB_CREDENTIALS"])
+			}
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Verify secrets and secrets_wo conflict
+			{
+				Config:      config(`secrets = [{ name = "DB_PASSWORD", value = "initial" }]`, "initial", 1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Conflicting secrets Configuration"),
+			},
+			// Verify Function Creation with write-only secrets
+			{
+				Config: config("", "initial", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(testCloudFunctionResourceFullPath, "secrets_wo"),
+					resource.TestCheckNoResourceAttr(testCloudFunctionResourceFullPath, "secrets_wo.%"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "secrets_wo_version", "1"),
+					func(state *terraform.State) error {
+						attributes := state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes
+						functionID, versionID = attributes["id"], attributes["version_id"]
+						return nil
+					},
+					testCheckFakeSecret("initial"),
+				),
+			},
+			// Verify changing secrets_wo alone updates nothing
+			{
+				Config: config("", "rotated", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: testCheckFakeSecret("initial"),
+			},
+			// Verify the secrets are rotated in place with secrets_wo_version
+			{
+				Config: config("", "rotated", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(testCloudFunctionResourceFullPath, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "version_id", &versionID),
+					testCheckFakeSecret("rotated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionResource_FunctionAuthorizedPartiesScope(t *testing.T) {
+	var functionName = uuid.New().String()
+	var testCloudFunctionResourceName = fmt.Sprintf("terraform-cloud-function-integ-resource-%s", functionName)
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", testCloudFunctionResourceName)
+
+	var functionID, blueVersionID, greenVersionID string
+
+	config := func(description string, scope string) string {
+		return fmt.Sprintf(`
+				resource "ngc_cloud_function" "%s" {
+					function_name           = "%s"
+					container_image         = "%s"
+					inference_port          = %d
+					inference_url           = "%s"
+					health                    = {
+						uri                  = "%s"
+						port                 = %d
+						expected_status_code = 200
+						timeout              = "PT10S"
+						protocol             = "HTTP"
+					}
+					api_body_format          = "%s"
+					description              = "%s"
+					rollout_strategy         = "blue_green"
+					authorized_parties       = [
+						{
+							nca_id = "%s"
+						}
+					]
+					authorized_parties_scope = "%s"
+				}
+				`,
+			testCloudFunctionResourceName,
+			functionName,
+			testutils.TestContainerUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerInferenceUrl,
+			testutils.TestContainerHealthUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerAPIFormat,
+			description,
+			testutils.TestAuthorizedParty1,
+			scope,
+		)
+	}
+
+	// The checks run once the IDs are saved by the previous checks of the step.
+	testCheckParties := func(versionID *string, versionParties []string, functionParties []string) resource.TestCheckFunc {
+		return func(state *terraform.State) error {
+			if err := testCheckAuthorizedParties(functionID, *versionID, versionParties, functionParties)(state); err != nil {
+				return err
+			}
+			return testCheckAuthorizedParties(functionID, "", functionParties, versionParties)(state)
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify authorized_parties_scope validation
+			{
+				Config:      config("blue", "all"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid authorized_parties_scope Configuration"),
+			},
+			// Verify Function Creation with function level authorized parties
+			{
+				Config: config("blue", "function"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "authorized_parties_scope", "function"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "authorized_parties.#", "1"),
+					func(state *terraform.State) error {
+						attributes := state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes
+						functionID, blueVersionID = attributes["id"], attributes["version_id"]
+						return nil
+					},
+					testCheckParties(&blueVersionID, nil, []string{testutils.TestAuthorizedParty1}),
+				),
+			},
+			// Verify the parties keep their access while the rollout replaces the version
+			{
+				Config: config("green", "function"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "id", &functionID),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "authorized_parties.#", "1"),
+					func(state *terraform.State) error {
+						greenVersionID = state.RootModule().Resources[testCloudFunctionResourceFullPath].Primary.Attributes["version_id"]
+						if greenVersionID == blueVersionID {
+							return fmt.Errorf("expected a new function version, got %s", greenVersionID)
+						}
+						return nil
+					},
+					testCheckParties(&greenVersionID, nil, []string{testutils.TestAuthorizedParty1}),
+				),
+			},
+			{
+				Config: config("green", "function"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Verify the parties move to the version scope
+			{
+				Config: config("green", "version"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(testCloudFunctionResourceFullPath, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "authorized_parties_scope", "version"),
+					resource.TestCheckResourceAttrPtr(testCloudFunctionResourceFullPath, "version_id", &greenVersionID),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "authorized_parties.#", "1"),
+					testCheckParties(&greenVersionID, []string{testutils.TestAuthorizedParty1}, nil),
+				),
+			},
+		},
+	})
+}
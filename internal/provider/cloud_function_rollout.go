@@ -0,0 +1,437 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+const (
+	// rolloutStrategyRecreate replaces the resource, deleting the old version before creating the new one.
+	rolloutStrategyRecreate = "recreate"
+	// rolloutStrategyBlueGreen creates and deploys the new version of the function before deleting the old one.
+	rolloutStrategyBlueGreen = "blue_green"
+	// rolloutStrategyCanary deploys the new version of the function next to the old one and shifts the capacity in steps.
+	rolloutStrategyCanary = "canary"
+)
+
+var rolloutStrategies = []string{rolloutStrategyRecreate, rolloutStrategyBlueGreen, rolloutStrategyCanary}
+
+// replacedVersionTag tags the versions replaced by a rollout which could not be deleted, ngc_cloud_function_family
+// deletes them beyond its retained_versions.
+const replacedVersionTag = "terraform-replaced-version"
+
+const rolloutRequiresReplaceDescription = "Changing this value creates a new function version, replacing the resource unless rollout_strategy is \"blue_green\" or \"canary\"."
+
+// rollsOutNewVersion tells whether the rollout strategy updates the resource with a new function version.
+func rollsOutNewVersion(rolloutStrategy types.String) bool {
+	return rolloutStrategy.ValueString() == rolloutStrategyBlueGreen || rolloutStrategy.ValueString() == rolloutStrategyCanary
+}
+
+// rolloutRequiresReplace tells whether a change to a function version attribute replaces the resource,
+// that is unless the planned rollout_strategy rolls out a new version in place.
+func rolloutRequiresReplace(ctx context.Context, plan tfsdk.Plan, diags *diag.Diagnostics) bool {
+	var rolloutStrategy types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("rollout_strategy"), &rolloutStrategy)...)
+	return !rollsOutNewVersion(rolloutStrategy)
+}
+
+func stringRolloutRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = rolloutRequiresReplace(ctx, req.Plan, &resp.Diagnostics)
+		},
+		rolloutRequiresReplaceDescription,
+		rolloutRequiresReplaceDescription,
+	)
+}
+
+func int64RolloutRequiresReplace() planmodifier.Int64 {
+	return int64planmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = rolloutRequiresReplace(ctx, req.Plan, &resp.Diagnostics)
+		},
+		rolloutRequiresReplaceDescription,
+		rolloutRequiresReplaceDescription,
+	)
+}
+
+func setRolloutRequiresReplace() planmodifier.Set {
+	return setplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.SetRequest, resp *setplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = rolloutRequiresReplace(ctx, req.Plan, &resp.Diagnostics)
+		},
+		rolloutRequiresReplaceDescription,
+		rolloutRequiresReplaceDescription,
+	)
+}
+
+func objectRolloutRequiresReplace() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = rolloutRequiresReplace(ctx, req.Plan, &resp.Diagnostics)
+		},
+		rolloutRequiresReplaceDescription,
+		rolloutRequiresReplaceDescription,
+	)
+}
+
+// functionVersionChanged reports whether the plan changes an attribute only set when creating a function version.
+func functionVersionChanged(plan NvidiaCloudFunctionResourceModel, state NvidiaCloudFunctionResourceModel) bool {
+	return !plan.HelmChart.Equal(state.HelmChart) ||
+		!plan.HelmChartServiceName.Equal(state.HelmChartServiceName) ||
+		!plan.InferencePort.Equal(state.InferencePort) ||
+		!plan.ContainerImage.Equal(state.ContainerImage) ||
+		!plan.ContainerArgs.Equal(state.ContainerArgs) ||
+		!plan.ContainerEnvironment.Equal(state.ContainerEnvironment) ||
+		!plan.InferenceUrl.Equal(state.InferenceUrl) ||
+		!plan.HealthUri.Equal(state.HealthUri) ||
+		!plan.Health.Equal(state.Health) ||
+		!plan.Resources.Equal(state.Resources) ||
+		!plan.Models.Equal(state.Models) ||
+		!plan.Description.Equal(state.Description) ||
+		!plan.FunctionType.Equal(state.FunctionType) ||
+		!plan.APIBodyFormat.Equal(state.APIBodyFormat)
+}
+
+func (r *NvidiaCloudFunctionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NvidiaCloudFunctionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.DeploymentSpecifications.IsNull() && !data.Deployments.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deployments"),
+			"Conflicting deployments Configuration",
+			"deployments and deployment_specifications describe the same deployment, only one of them can be set.",
+		)
+	}
+
+	if !data.Secrets.IsNull() && !data.SecretsWo.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secrets_wo"),
+			"Conflicting secrets Configuration",
+			"secrets_wo and secrets both set the secrets of the function version, only one of them can be set.",
+		)
+	}
+
+	if !data.AuthorizedPartiesScope.IsNull() && !data.AuthorizedPartiesScope.IsUnknown() && !slices.Contains(authorizationScopes, data.AuthorizedPartiesScope.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("authorized_parties_scope"),
+			"Invalid authorized_parties_scope Configuration",
+			fmt.Sprintf("authorized_parties_scope must be one of %q, got %q.", authorizationScopes, data.AuthorizedPartiesScope.ValueString()),
+		)
+	}
+
+	if data.RolloutStrategy.IsUnknown() {
+		return
+	}
+
+	if !data.RolloutStrategy.IsNull() && !slices.Contains(rolloutStrategies, data.RolloutStrategy.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rollout_strategy"),
+			"Invalid rollout_strategy Configuration",
+			fmt.Sprintf("rollout_strategy must be one of %q, got %q.", rolloutStrategies, data.RolloutStrategy.ValueString()),
+		)
+		return
+	}
+
+	if data.RolloutStrategy.ValueString() != rolloutStrategyCanary {
+		if !data.Canary.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("canary"),
+				"Invalid canary Configuration",
+				"The canary block requires rollout_strategy to be \"canary\".",
+			)
+		}
+		return
+	}
+
+	if data.DeploymentSpecifications.IsNull() && data.Deployments.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deployment_specifications"),
+			"Missing deployment_specifications Configuration",
+			"The \"canary\" rollout_strategy shifts the capacity of deployment_specifications or deployments, one of them must be set.",
+		)
+	}
+
+	if data.Canary.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("canary"),
+			"Missing canary Configuration",
+			"The \"canary\" rollout_strategy requires the canary block.",
+		)
+		return
+	}
+
+	var canary NvidiaCloudFunctionResourceCanaryModel
+	resp.Diagnostics.Append(data.Canary.As(ctx, &canary, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateCanaryConfig(ctx, canary, &resp.Diagnostics)
+}
+
+func validateCanaryConfig(ctx context.Context, canary NvidiaCloudFunctionResourceCanaryModel, diag *diag.Diagnostics) {
+	if !canary.Pause.IsNull() && !canary.Pause.IsUnknown() {
+		if pause, err := time.ParseDuration(canary.Pause.ValueString()); err != nil || pause < 0 {
+			diag.AddAttributeError(
+				path.Root("canary").AtName("pause"),
+				"Invalid canary Configuration",
+				fmt.Sprintf("pause must be a non-negative duration such as \"5m\", got %q.", canary.Pause.ValueString()),
+			)
+		}
+	}
+
+	if canary.Steps.IsUnknown() {
+		return
+	}
+
+	if len(canary.Steps.Elements()) == 0 {
+		diag.AddAttributeError(
+			path.Root("canary").AtName("steps"),
+			"Missing canary Configuration",
+			"steps must list at least one percentage of the capacity shifted to the new version, such as [10, 50].",
+		)
+		return
+	}
+
+	steps := make([]types.Int64, 0, len(canary.Steps.Elements()))
+	diag.Append(canary.Steps.ElementsAs(ctx, &steps, false)...)
+
+	var previous int64
+	for i, step := range steps {
+		if step.IsUnknown() {
+			continue
+		}
+		if step.IsNull() || step.ValueInt64() <= previous || step.ValueInt64() > 100 {
+			diag.AddAttributeError(
+				path.Root("canary").AtName("steps").AtListIndex(i),
+				"Invalid canary Configuration",
+				"steps must be increasing percentages between 1 and 100.",
+			)
+			return
+		}
+		previous = step.ValueInt64()
+	}
+}
+
+// ModifyPlan marks version_id unknown when a blue/green or canary rollout creates a new function version.
+func (r *NvidiaCloudFunctionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to roll out on creation or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state NvidiaCloudFunctionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rollsOutNewVersion(plan.RolloutStrategy) && functionVersionChanged(plan, state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version_id"), types.StringUnknown())...)
+	}
+}
+
+// blueGreenRollout creates and deploys a new version of the function, then deletes the old version.
+// The old version is left untouched until the new one is ACTIVE, and the failed new version is
+// deleted unless keep_failed_resource is set.
+func (r *NvidiaCloudFunctionResource) blueGreenRollout(ctx context.Context, plan NvidiaCloudFunctionResourceModel, state NvidiaCloudFunctionResourceModel, resp *resource.UpdateResponse) {
+	// The response state is the plan by default, keep the old version in it until the new one is ACTIVE.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	function, authorizedAccounts := r.createRolloutVersion(ctx, plan, state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var deployment *utils.NvidiaCloudFunctionDeployment
+	if plan.hasDeployment() {
+		functionDeployment := r.createDeployment(ctx, plan, &resp.Diagnostics, *function)
+
+		if resp.Diagnostics.HasError() {
+			// The context may be done after a timeout, the failed version is deleted anyway.
+			r.deleteFailedDeploymentVersion(context.WithoutCancel(ctx), plan.KeepFailedResource.ValueBool(), function.ID, function.VersionID, &resp.Diagnostics)
+			return
+		}
+		deployment = &functionDeployment
+	}
+
+	r.completeRollout(ctx, plan, state, function, deployment, authorizedAccounts, resp)
+}
+
+// rolloutUpdatesFunctionAuthorizedParties reports whether a rollout updates the parties authorized at the function
+// level, which also invoke the old version.
+func rolloutUpdatesFunctionAuthorizedParties(plan NvidiaCloudFunctionResourceModel, state NvidiaCloudFunctionResourceModel) bool {
+	return plan.AuthorizedPartiesScope.ValueString() == authorizationScopeFunction ||
+		state.AuthorizedPartiesScope.ValueString() == authorizationScopeFunction
+}
+
+// completeRollout saves the deployed new version in the state, then deletes the old version. The parties
+// authorized at the function level are only updated by then, the new version being first saved with the
+// prior ones, so that a failed rollout leaves them untouched.
+func (r *NvidiaCloudFunctionResource) completeRollout(
+	ctx context.Context,
+	plan NvidiaCloudFunctionResourceModel,
+	state NvidiaCloudFunctionResourceModel,
+	function *utils.NvidiaCloudFunctionInfo,
+	deployment *utils.NvidiaCloudFunctionDeployment,
+	authorizedAccounts utils.AuthorizeAccountsToInvokeFunctionResponse,
+	resp *resource.UpdateResponse,
+) {
+	if rolloutUpdatesFunctionAuthorizedParties(plan, state) {
+		saved := plan
+		saved.AuthorizedPartiesScope = state.AuthorizedPartiesScope
+		priorAuthorizedAccounts := utils.AuthorizeAccountsToInvokeFunctionResponse{
+			Function: utils.AuthorizeAccountsToInvokeFunctionResponseFunctionInfo{
+				Id:                function.ID,
+				VersionID:         function.VersionID,
+				AuthorizedParties: functionAuthorizedParties(ctx, state.AuthorizedParties, &resp.Diagnostics),
+			},
+		}
+		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &saved, function, deployment, &priorAuthorizedAccounts)
+		setSecretsHash(ctx, resp.Private, saved, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &saved)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The old version is kept when the parties could not be updated, they may be its only ones.
+		authorizedAccounts = updateFunctionAuthorizedPartiesScope(ctx, function.ID, function.VersionID, plan, &state, &resp.Diagnostics, *r.client)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &plan, function, deployment, &authorizedAccounts)
+	setSecretsHash(ctx, resp.Private, plan, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.deletePreviousVersion(ctx, state, &resp.Diagnostics)
+}
+
+// createRolloutVersion creates the new version of the function being rolled out. Only the new version is
+// authorized, the parties authorized at the function level already invoke it and are updated by completeRollout.
+func (r *NvidiaCloudFunctionResource) createRolloutVersion(
+	ctx context.Context,
+	plan NvidiaCloudFunctionResourceModel,
+	state NvidiaCloudFunctionResourceModel,
+	diag *diag.Diagnostics,
+) (*utils.NvidiaCloudFunctionInfo, utils.AuthorizeAccountsToInvokeFunctionResponse) {
+	request := r.createOrUpdateRequest(ctx, plan, diag)
+
+	if diag.HasError() {
+		return nil, utils.AuthorizeAccountsToInvokeFunctionResponse{}
+	}
+
+	createNvidiaCloudFunctionResponse, err := r.client.CreateNvidiaCloudFunction(ctx, state.Id.ValueString(), request)
+
+	if err != nil {
+		diag.AddError(
+			"Failed to create Cloud Function version",
+			utils.ErrorDetail(err),
+		)
+		return nil, utils.AuthorizeAccountsToInvokeFunctionResponse{}
+	}
+
+	function := createNvidiaCloudFunctionResponse.Function
+	tflog.Info(ctx, fmt.Sprintf("rolling out Cloud Function version %s to replace version %s", function.VersionID, state.VersionID.ValueString()))
+
+	var authorizedAccounts utils.AuthorizeAccountsToInvokeFunctionResponse
+	if plan.AuthorizedPartiesScope.ValueString() != authorizationScopeFunction {
+		target := functionVersionAuthorizationTarget(function.ID, function.VersionID)
+		authorizedAccounts = updateFunctionAuthorizedParties(ctx, target, plan.AuthorizedParties, types.SetNull(authorizedPartiesSchema().NestedObject.Type()), diag, *r.client)
+	}
+
+	if diag.HasError() {
+		r.deleteFailedDeploymentVersion(context.WithoutCancel(ctx), plan.KeepFailedResource.ValueBool(), function.ID, function.VersionID, diag)
+		return nil, utils.AuthorizeAccountsToInvokeFunctionResponse{}
+	}
+	return &function, authorizedAccounts
+}
+
+// deletePreviousVersion deletes the version replaced by a rollout. The new version is ACTIVE and
+// saved in the state by then, failing to delete the old one only leaves it behind, tagged with
+// replacedVersionTag.
+func (r *NvidiaCloudFunctionResource) deletePreviousVersion(ctx context.Context, state NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	if state.hasDeployment() {
+		_, err := r.client.DeleteNvidiaCloudFunctionDeployment(ctx, state.Id.ValueString(), state.VersionID.ValueString())
+		if err != nil && !utils.IsNotFound(err) {
+			diag.AddWarning(
+				fmt.Sprintf("Failed to delete Cloud Function Deployment %s", state.VersionID.ValueString()),
+				utils.ErrorDetail(err),
+			)
+			r.tagReplacedVersion(ctx, state, diag)
+			return
+		}
+	}
+
+	err := r.client.DeleteNvidiaCloudFunctionVersion(ctx, state.Id.ValueString(), state.VersionID.ValueString())
+	if err != nil && !utils.IsNotFound(err) {
+		diag.AddWarning(
+			fmt.Sprintf("Failed to delete Cloud Function version %s", state.VersionID.ValueString()),
+			utils.ErrorDetail(err),
+		)
+		r.tagReplacedVersion(ctx, state, diag)
+	}
+}
+
+// tagReplacedVersion tags the version left behind by a rollout with replacedVersionTag, for ngc_cloud_function_family
+// to delete it. The context may be done after a timeout, the version is tagged anyway.
+func (r *NvidiaCloudFunctionResource) tagReplacedVersion(ctx context.Context, state NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	tags := make([]string, 0, len(state.Tags.Elements())+1)
+	if !state.Tags.IsNull() && !state.Tags.IsUnknown() {
+		diag.Append(state.Tags.ElementsAs(ctx, &tags, false)...)
+	}
+	tags = append(tags, replacedVersionTag)
+
+	_, err := r.client.UpdateNvidiaCloudFunctionMetadata(context.WithoutCancel(ctx), state.Id.ValueString(), state.VersionID.ValueString(), utils.UpdateNvidiaCloudFunctionMetadataRequest{
+		Tags: tags,
+	})
+	if err != nil && !utils.IsNotFound(err) {
+		diag.AddWarning(
+			fmt.Sprintf("Failed to tag Cloud Function version %s as replaced", state.VersionID.ValueString()),
+			utils.ErrorDetail(err),
+		)
+	}
+}
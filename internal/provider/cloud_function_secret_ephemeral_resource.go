@@ -0,0 +1,150 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &NvidiaCloudFunctionSecretEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &NvidiaCloudFunctionSecretEphemeralResource{}
+
+func NewNvidiaCloudFunctionSecretEphemeralResource() ephemeral.EphemeralResource {
+	return &NvidiaCloudFunctionSecretEphemeralResource{}
+}
+
+// NvidiaCloudFunctionSecretEphemeralResource defines the ephemeral resource implementation.
+// It builds a Cloud Function secret for secrets_wo without storing it anywhere.
+type NvidiaCloudFunctionSecretEphemeralResource struct{}
+
+// NvidiaCloudFunctionSecretEphemeralResourceModel describes the ephemeral resource data model.
+type NvidiaCloudFunctionSecretEphemeralResourceModel struct {
+	Name   types.String `tfsdk:"name"`
+	Value  types.String `tfsdk:"value"`
+	Values types.Map    `tfsdk:"values"`
+	Secret types.Map    `tfsdk:"secret"`
+}
+
+func (r *NvidiaCloudFunctionSecretEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_function_secret"
+}
+
+func (r *NvidiaCloudFunctionSecretEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Builds a Cloud Function secret from other ephemeral values, such as secrets read from a vault, " +
+			"to set in the write-only `secrets_wo` of `ngc_cloud_function`. The secret is never stored in the state or the plan.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Secret name",
+				Required:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Secret value. Must be a string or json node. Conflicts with `values`, one of them is required.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "Secret values, sent as a json node with a key for each value. Conflicts with `value`, one of them is required.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"secret": schema.MapAttribute{
+				MarkdownDescription: "The secret, mapping its name to its value. Several secrets can be merged into `secrets_wo`, " +
+					"such as `merge(ephemeral.ngc_cloud_function_secret.a.secret, ephemeral.ngc_cloud_function_secret.b.secret)`.",
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *NvidiaCloudFunctionSecretEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data NvidiaCloudFunctionSecretEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Value.IsNull() && !data.Values.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("values"),
+			"Conflicting secret Configuration",
+			"value and values both set the value of the secret, only one of them can be set.",
+		)
+	}
+
+	if data.Value.IsNull() && data.Values.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("value"),
+			"Missing secret Configuration",
+			"The value of the secret must be set with value or values.",
+		)
+	}
+}
+
+func (r *NvidiaCloudFunctionSecretEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data NvidiaCloudFunctionSecretEphemeralResourceModel
+
+	// Read Terraform config data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	value := ephemeralSecretValue(ctx, data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret, diags := types.MapValueFrom(ctx, types.StringType, map[string]string{data.Name.ValueString(): value})
+	resp.Diagnostics.Append(diags...)
+	data.Secret = secret
+
+	// Save data into ephemeral result data
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// ephemeralSecretValue returns the value of the secret, values being encoded as a json node.
+func ephemeralSecretValue(ctx context.Context, data NvidiaCloudFunctionSecretEphemeralResourceModel, diag *diag.Diagnostics) string {
+	if data.Values.IsNull() {
+		return data.Value.ValueString()
+	}
+
+	values := make(map[string]string, len(data.Values.Elements()))
+	diag.Append(data.Values.ElementsAs(ctx, &values, false)...)
+
+	if diag.HasError() {
+		return ""
+	}
+
+	value, err := json.Marshal(values)
+	if err != nil {
+		diag.AddError(
+			"Failed to encode Cloud Function secret values",
+			err.Error(),
+		)
+		return ""
+	}
+	return string(value)
+}
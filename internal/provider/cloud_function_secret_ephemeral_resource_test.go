@@ -0,0 +1,140 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEphemeralSecretValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data NvidiaCloudFunctionSecretEphemeralResourceModel
+		want string
+	}{
+		{
+			name: "Value",
+			data: NvidiaCloudFunctionSecretEphemeralResourceModel{
+				Value:  types.StringValue("password"),
+				Values: types.MapNull(types.StringType),
+			},
+			want: "password",
+		},
+		{
+			name: "Values",
+			data: NvidiaCloudFunctionSecretEphemeralResourceModel{
+				Value: types.StringNull(),
+				Values: types.MapValueMust(types.StringType, map[string]attr.Value{
+					"username": types.StringValue("admin"),
+					"password": types.StringValue("password"),
+				}),
+			},
+			want: `{"password":"password","username":"admin"}`,
+		},
+		{
+			name: "NoValue",
+			data: NvidiaCloudFunctionSecretEphemeralResourceModel{
+				Value:  types.StringNull(),
+				Values: types.MapNull(types.StringType),
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			assert.Equal(t, tt.want, ephemeralSecretValue(context.Background(), tt.data, &diags))
+			assert.False(t, diags.HasError(), "ephemeralSecretValue() diagnostics = %v", diags)
+		})
+	}
+}
+
+func TestNvidiaCloudFunctionSecretEphemeralResource_ValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionSecretEphemeralResource{}
+
+	var schemaResp ephemeral.SchemaResponse
+	r.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+
+	valuesType := tftypes.Map{ElementType: tftypes.String}
+
+	tests := []struct {
+		name    string
+		value   tftypes.Value
+		values  tftypes.Value
+		wantErr bool
+	}{
+		{
+			name:   "Value",
+			value:  tftypes.NewValue(tftypes.String, "password"),
+			values: tftypes.NewValue(valuesType, nil),
+		},
+		{
+			name:   "Values",
+			value:  tftypes.NewValue(tftypes.String, nil),
+			values: tftypes.NewValue(valuesType, map[string]tftypes.Value{"password": tftypes.NewValue(tftypes.String, "password")}),
+		},
+		{
+			name:   "UnknownValue",
+			value:  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			values: tftypes.NewValue(valuesType, nil),
+		},
+		{
+			name:    "ValueAndValues",
+			value:   tftypes.NewValue(tftypes.String, "password"),
+			values:  tftypes.NewValue(valuesType, map[string]tftypes.Value{"password": tftypes.NewValue(tftypes.String, "password")}),
+			wantErr: true,
+		},
+		{
+			name:    "NoValue",
+			value:   tftypes.NewValue(tftypes.String, nil),
+			values:  tftypes.NewValue(valuesType, nil),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"name":   tftypes.NewValue(tftypes.String, "password"),
+					"value":  tt.value,
+					"values": tt.values,
+					"secret": tftypes.NewValue(valuesType, nil),
+				}),
+			}
+
+			var resp ephemeral.ValidateConfigResponse
+			r.ValidateConfig(ctx, ephemeral.ValidateConfigRequest{Config: config}, &resp)
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), "ValidateConfig() diagnostics = %v", resp.Diagnostics)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// secretsPrivateStateKey is the private state key of the hash of the secrets applied to the function version.
+// NVCF never returns the secret values, the hash tells whether the planned secrets were applied.
+const secretsPrivateStateKey = "secrets"
+
+// writeOnlySecretsHash stands for the hash of secrets_wo, which are never hashed so that nothing derived
+// from them is stored. It matches no secrets, removing secrets_wo or moving back to secrets updates them.
+const writeOnlySecretsHash = "write-only"
+
+// privateState is the private state of a resource, as found in the framework requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+type secretsPrivateStateData struct {
+	Hash string `json:"hash"`
+}
+
+// prepareSecrets converts the secrets to the NVCF request. A value that is valid JSON is sent as a JSON node,
+// any other value as a string.
+func prepareSecrets(ctx context.Context, secretsRawData basetypes.SetValue, diag *diag.Diagnostics) []utils.NvidiaCloudFunctionSecret {
+	if secretsRawData.IsNull() || secretsRawData.IsUnknown() {
+		return nil
+	}
+
+	secrets := make([]NvidiaCloudFunctionResourceSecretModel, 0)
+	diag.Append(secretsRawData.ElementsAs(ctx, &secrets, false)...)
+
+	if diag.HasError() {
+		return nil
+	}
+
+	secretsOption := make([]utils.NvidiaCloudFunctionSecret, 0, len(secrets))
+	for _, v := range secrets {
+		if v.Value.ValueString() != "" {
+			secretsOption = append(secretsOption, utils.NvidiaCloudFunctionSecret{
+				Name:  v.Name.ValueString(),
+				Value: secretValue(v.Value.ValueString()),
+			})
+		}
+	}
+
+	sortSecrets(secretsOption)
+	return secretsOption
+}
+
+// prepareWriteOnlySecrets converts the secrets_wo map of secret names to values to the NVCF request,
+// like prepareSecrets.
+func prepareWriteOnlySecrets(ctx context.Context, secretsRawData basetypes.MapValue, diag *diag.Diagnostics) []utils.NvidiaCloudFunctionSecret {
+	if secretsRawData.IsNull() || secretsRawData.IsUnknown() {
+		return nil
+	}
+
+	secrets := make(map[string]string, len(secretsRawData.Elements()))
+	diag.Append(secretsRawData.ElementsAs(ctx, &secrets, false)...)
+
+	if diag.HasError() {
+		return nil
+	}
+
+	secretsOption := make([]utils.NvidiaCloudFunctionSecret, 0, len(secrets))
+	for name, value := range secrets {
+		if value != "" {
+			secretsOption = append(secretsOption, utils.NvidiaCloudFunctionSecret{
+				Name:  name,
+				Value: secretValue(value),
+			})
+		}
+	}
+
+	sortSecrets(secretsOption)
+	return secretsOption
+}
+
+// secretValue returns the JSON node of a secret value that is valid JSON, the value itself otherwise.
+func secretValue(value string) interface{} {
+	var node interface{}
+
+	// When the input is not a valid json, we will put it as string directly.
+	if err := json.Unmarshal([]byte(value), &node); err != nil {
+		return value
+	}
+	return node
+}
+
+func sortSecrets(secrets []utils.NvidiaCloudFunctionSecret) {
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+}
+
+// secrets returns the secrets of the function version, from secrets_wo when it is set in the configuration.
+// Write-only values are null in the plan, they must be read from the configuration first, see readWriteOnlySecrets.
+func (m NvidiaCloudFunctionResourceModel) secrets(ctx context.Context, diag *diag.Diagnostics) []utils.NvidiaCloudFunctionSecret {
+	if !m.SecretsWo.IsNull() {
+		return prepareWriteOnlySecrets(ctx, m.SecretsWo, diag)
+	}
+	return prepareSecrets(ctx, m.Secrets, diag)
+}
+
+// readWriteOnlySecrets reads secrets_wo from the configuration into the model.
+func readWriteOnlySecrets(ctx context.Context, config tfsdk.Config, m *NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	diag.Append(config.GetAttribute(ctx, path.Root("secrets_wo"), &m.SecretsWo)...)
+}
+
+// secretsHash returns the SHA-256 of the secrets, sorted by name.
+func secretsHash(secrets []utils.NvidiaCloudFunctionSecret) string {
+	if secrets == nil {
+		secrets = []utils.NvidiaCloudFunctionSecret{}
+	}
+
+	// The secrets are sorted and the keys of JSON nodes are marshaled in order, so the hash is stable.
+	content, _ := json.Marshal(secrets)
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// getSecretsHash returns the hash of the secrets last applied to the function version, if any.
+func getSecretsHash(ctx context.Context, private privateState, diag *diag.Diagnostics) (string, bool) {
+	value, diags := private.GetKey(ctx, secretsPrivateStateKey)
+	diag.Append(diags...)
+
+	if diags.HasError() || value == nil {
+		return "", false
+	}
+
+	var data secretsPrivateStateData
+	if err := json.Unmarshal(value, &data); err != nil {
+		diag.AddError(
+			"Failed to read Cloud Function secrets private state",
+			err.Error(),
+		)
+		return "", false
+	}
+	return data.Hash, true
+}
+
+// setSecretsHash records the hash of the secrets applied to the function version.
+func setSecretsHash(ctx context.Context, private privateState, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	hash := writeOnlySecretsHash
+	if data.SecretsWo.IsNull() {
+		hash = secretsHash(prepareSecrets(ctx, data.Secrets, diag))
+	}
+
+	value, _ := json.Marshal(secretsPrivateStateData{Hash: hash})
+	diag.Append(private.SetKey(ctx, secretsPrivateStateKey, value)...)
+}
+
+// updateSecrets applies the planned secrets to the function version when they differ from the secrets last applied,
+// known from the private state hash or, for a resource without one, from the prior state. secrets_wo are applied
+// when secrets_wo_version changes, or when they replace secrets.
+func (r *NvidiaCloudFunctionResource) updateSecrets(
+	ctx context.Context,
+	plan NvidiaCloudFunctionResourceModel,
+	state NvidiaCloudFunctionResourceModel,
+	prior privateState,
+	private privateState,
+	diag *diag.Diagnostics,
+) {
+	secrets := plan.secrets(ctx, diag)
+
+	appliedHash, ok := getSecretsHash(ctx, prior, diag)
+	if !ok {
+		appliedHash = secretsHash(prepareSecrets(ctx, state.Secrets, diag))
+	}
+
+	if diag.HasError() {
+		return
+	}
+
+	if plan.SecretsWo.IsNull() {
+		if secretsHash(secrets) == appliedHash {
+			return
+		}
+	} else if appliedHash == writeOnlySecretsHash && plan.SecretsWoVersion.Equal(state.SecretsWoVersion) {
+		return
+	}
+
+	// Removing every secret sends an empty list, rather than a null NVCF may not take as clearing the secrets.
+	if secrets == nil {
+		secrets = []utils.NvidiaCloudFunctionSecret{}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("updating the secrets of Cloud Function version %s", state.VersionID.ValueString()))
+
+	err := r.client.UpdateNvidiaCloudFunctionSecrets(ctx, state.Id.ValueString(), state.VersionID.ValueString(), utils.UpdateNvidiaCloudFunctionSecretsRequest{
+		Secrets: secrets,
+	})
+	if err != nil {
+		diag.AddError(
+			"Failed to update Cloud Function secrets",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	setSecretsHash(ctx, private, plan, diag)
+}
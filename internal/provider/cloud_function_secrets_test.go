@@ -0,0 +1,271 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func testSecrets(t *testing.T, secrets map[string]string) types.Set {
+	t.Helper()
+
+	models := make([]NvidiaCloudFunctionResourceSecretModel, 0, len(secrets))
+	for name, value := range secrets {
+		models = append(models, NvidiaCloudFunctionResourceSecretModel{
+			Name:  types.StringValue(name),
+			Value: types.StringValue(value),
+		})
+	}
+
+	set, diags := types.SetValueFrom(context.Background(), secretsSchema().NestedObject.Type(), models)
+	if diags.HasError() {
+		t.Fatalf("SetValueFrom() diagnostics = %v", diags)
+	}
+	return set
+}
+
+func TestPrepareSecrets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		secrets types.Set
+		want    []utils.NvidiaCloudFunctionSecret
+	}{
+		{
+			name:    "Null",
+			secrets: types.SetNull(secretsSchema().NestedObject.Type()),
+			want:    nil,
+		},
+		{
+			name:    "SortedByName",
+			secrets: testSecrets(t, map[string]string{"B": "b", "A": "a"}),
+			want: []utils.NvidiaCloudFunctionSecret{
+				{Name: "A", Value: "a"},
+				{Name: "B", Value: "b"},
+			},
+		},
+		{
+			name:    "JSONNode",
+			secrets: testSecrets(t, map[string]string{"CONFIG": `{"key": "value"}`}),
+			want: []utils.NvidiaCloudFunctionSecret{
+				{Name: "CONFIG", Value: map[string]interface{}{"key": "value"}},
+			},
+		},
+		{
+			name:    "EmptyValue",
+			secrets: testSecrets(t, map[string]string{"EMPTY": ""}),
+			want:    []utils.NvidiaCloudFunctionSecret{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			assert.Equal(t, tt.want, prepareSecrets(context.Background(), tt.secrets, &diags))
+			assert.False(t, diags.HasError(), "prepareSecrets() diagnostics = %v", diags)
+		})
+	}
+}
+
+func TestPrepareWriteOnlySecrets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		secrets types.Map
+		want    []utils.NvidiaCloudFunctionSecret
+	}{
+		{
+			name:    "Null",
+			secrets: types.MapNull(types.StringType),
+			want:    nil,
+		},
+		{
+			name: "SortedByName",
+			secrets: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"B": types.StringValue("b"),
+				"A": types.StringValue("a"),
+			}),
+			want: []utils.NvidiaCloudFunctionSecret{
+				{Name: "A", Value: "a"},
+				{Name: "B", Value: "b"},
+			},
+		},
+		{
+			name: "JSONNode",
+			secrets: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"CONFIG": types.StringValue(`{"key": "value"}`),
+			}),
+			want: []utils.NvidiaCloudFunctionSecret{
+				{Name: "CONFIG", Value: map[string]interface{}{"key": "value"}},
+			},
+		},
+		{
+			name: "EmptyValue",
+			secrets: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"EMPTY": types.StringValue(""),
+			}),
+			want: []utils.NvidiaCloudFunctionSecret{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			assert.Equal(t, tt.want, prepareWriteOnlySecrets(context.Background(), tt.secrets, &diags))
+			assert.False(t, diags.HasError(), "prepareWriteOnlySecrets() diagnostics = %v", diags)
+		})
+	}
+}
+
+func TestSecretsHash(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	hash := func(secrets map[string]string) string {
+		return secretsHash(prepareSecrets(ctx, testSecrets(t, secrets), &diags))
+	}
+
+	assert.Equal(t, hash(map[string]string{"A": "a", "B": "b"}), hash(map[string]string{"B": "b", "A": "a"}))
+	assert.NotEqual(t, hash(map[string]string{"A": "a"}), hash(map[string]string{"A": "rotated"}))
+	assert.Equal(t, secretsHash(nil), hash(map[string]string{}))
+	assert.False(t, diags.HasError(), "secretsHash() diagnostics = %v", diags)
+}
+
+func TestSecretsPrivateState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	private := testPrivateState{}
+	var diags diag.Diagnostics
+
+	_, ok := getSecretsHash(ctx, private, &diags)
+	assert.False(t, ok)
+
+	secrets := testSecrets(t, map[string]string{"DB_PASSWORD": "password"})
+	setSecretsHash(ctx, private, NvidiaCloudFunctionResourceModel{Secrets: secrets, SecretsWo: types.MapNull(types.StringType)}, &diags)
+
+	hash, ok := getSecretsHash(ctx, private, &diags)
+	assert.True(t, ok)
+	assert.Equal(t, secretsHash(prepareSecrets(ctx, secrets, &diags)), hash)
+	assert.False(t, diags.HasError(), "secrets private state diagnostics = %v", diags)
+
+	setSecretsHash(ctx, private, NvidiaCloudFunctionResourceModel{
+		Secrets:   types.SetNull(secretsSchema().NestedObject.Type()),
+		SecretsWo: types.MapValueMust(types.StringType, map[string]attr.Value{"DB_PASSWORD": types.StringValue("password")}),
+	}, &diags)
+
+	hash, ok = getSecretsHash(ctx, private, &diags)
+	assert.True(t, ok)
+	assert.Equal(t, writeOnlySecretsHash, hash)
+	assert.False(t, diags.HasError(), "secrets private state diagnostics = %v", diags)
+
+	private[secretsPrivateStateKey] = []byte("not json")
+	getSecretsHash(ctx, private, &diags)
+	assert.True(t, diags.HasError())
+}
+
+func TestNvidiaCloudFunctionResource_updateSecrets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		secrets  types.Set
+		wantBody string
+	}{
+		{
+			name:     "Rotated",
+			secrets:  testSecrets(t, map[string]string{"DB_PASSWORD": "rotated"}),
+			wantBody: `{"secrets":[{"name":"DB_PASSWORD","value":"rotated"}]}`,
+		},
+		{
+			name:     "AllRemoved",
+			secrets:  types.SetNull(secretsSchema().NestedObject.Type()),
+			wantBody: `{"secrets":[]}`,
+		},
+		{
+			name:     "AllRemovedEmptySet",
+			secrets:  testSecrets(t, map[string]string{}),
+			wantBody: `{"secrets":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				content, _ := io.ReadAll(r.Body)
+				body = string(content)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			r := &NvidiaCloudFunctionResource{
+				client: &utils.NVCFClient{
+					NgcEndpoint: server.URL,
+					NgcApiKey:   "mock-api-key",
+					NgcOrg:      "mock-org",
+					HttpClient:  server.Client(),
+				},
+			}
+
+			state := NvidiaCloudFunctionResourceModel{
+				Id:        types.StringValue("mock-function-id"),
+				VersionID: types.StringValue("mock-version-id"),
+				Secrets:   testSecrets(t, map[string]string{"DB_PASSWORD": "password"}),
+				SecretsWo: types.MapNull(types.StringType),
+			}
+			plan := state
+			plan.Secrets = tt.secrets
+
+			private := testPrivateState{}
+			var diags diag.Diagnostics
+			r.updateSecrets(context.Background(), plan, state, testPrivateState{}, private, &diags)
+
+			assert.False(t, diags.HasError(), "updateSecrets() diagnostics = %v", diags)
+			assert.JSONEq(t, tt.wantBody, body)
+
+			hash, ok := getSecretsHash(context.Background(), private, &diags)
+			assert.True(t, ok)
+			assert.Equal(t, secretsHash(prepareSecrets(context.Background(), tt.secrets, &diags)), hash)
+		})
+	}
+}
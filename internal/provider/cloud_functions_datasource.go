@@ -0,0 +1,521 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NvidiaCloudFunctionsDataSource{}
+
+// Maps the visibility exposed in the schema to the one used by NVCF API.
+var cloudFunctionVisibilities = map[string]string{
+	"private": "private",
+	"public":  "public",
+	"shared":  "authorized",
+}
+
+func NewNvidiaCloudFunctionsDataSource() datasource.DataSource {
+	return &NvidiaCloudFunctionsDataSource{}
+}
+
+// NvidiaCloudFunctionsDataSource defines the data source implementation.
+type NvidiaCloudFunctionsDataSource struct {
+	client *utils.NVCFClient
+}
+
+// NvidiaCloudFunctionsDataSourceModel describes the data source data model.
+type NvidiaCloudFunctionsDataSourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	NameRegex    types.String `tfsdk:"name_regex"`
+	Tags         types.Set    `tfsdk:"tags"`
+	Status       types.String `tfsdk:"status"`
+	FunctionType types.String `tfsdk:"function_type"`
+	Visibility   types.Set    `tfsdk:"visibility"`
+	Functions    types.List   `tfsdk:"functions"`
+}
+
+// NvidiaCloudFunctionsDataSourceFunctionModel describes a function version in the data source result.
+type NvidiaCloudFunctionsDataSourceFunctionModel struct {
+	FunctionID               types.String                            `tfsdk:"function_id"`
+	VersionID                types.String                            `tfsdk:"version_id"`
+	NcaId                    types.String                            `tfsdk:"nca_id"`
+	FunctionName             types.String                            `tfsdk:"function_name"`
+	Status                   types.String                            `tfsdk:"status"`
+	HelmChart                types.String                            `tfsdk:"helm_chart"`
+	HelmChartServiceName     types.String                            `tfsdk:"helm_chart_service_name"`
+	InferencePort            types.Int64                             `tfsdk:"inference_port"`
+	ContainerImage           types.String                            `tfsdk:"container_image"`
+	ContainerArgs            types.String                            `tfsdk:"container_args"`
+	ContainerEnvironment     types.Set                               `tfsdk:"container_environment"`
+	InferenceUrl             types.String                            `tfsdk:"inference_url"`
+	HealthUri                types.String                            `tfsdk:"health_uri"`
+	Health                   *NvidiaCloudFunctionResourceHealthModel `tfsdk:"health"`
+	APIBodyFormat            types.String                            `tfsdk:"api_body_format"`
+	DeploymentSpecifications types.List                              `tfsdk:"deployment_specifications"`
+	Tags                     types.Set                               `tfsdk:"tags"`
+	Description              types.String                            `tfsdk:"description"`
+	Models                   types.Set                               `tfsdk:"models"`
+	Resources                types.Set                               `tfsdk:"resources"`
+	FunctionType             types.String                            `tfsdk:"function_type"`
+	AuthorizedParties        types.Set                               `tfsdk:"authorized_parties"`
+}
+
+func newNvidiaCloudFunctionsDataSourceFunctionModel(
+	ctx context.Context, diag *diag.Diagnostics,
+	functionInfo *utils.NvidiaCloudFunctionInfo,
+	functionDeployment *utils.NvidiaCloudFunctionDeployment,
+	functionAuthorizedParties []utils.AuthorizedParty,
+) NvidiaCloudFunctionsDataSourceFunctionModel {
+	data := NvidiaCloudFunctionDataSourceModel{
+		ContainerEnvironment:     types.SetNull(containerEnvironmentsSchema().NestedObject.Type()),
+		DeploymentSpecifications: types.ListNull(deploymentSpecificationsSchema().NestedObject.Type()),
+		Tags:                     types.SetNull(types.StringType),
+		Models:                   types.SetNull(modelsSchema().NestedObject.Type()),
+		Resources:                types.SetNull(resourcesSchema().NestedObject.Type()),
+		AuthorizedParties:        types.SetNull(authorizedPartiesSchema().NestedObject.Type()),
+	}
+
+	(&NvidiaCloudFunctionDataSource{}).updateNvidiaCloudFunctionDataSourceModel(ctx, diag, &data, functionInfo, functionDeployment, functionAuthorizedParties)
+
+	return NvidiaCloudFunctionsDataSourceFunctionModel{
+		FunctionID:               data.FunctionID,
+		VersionID:                data.VersionID,
+		NcaId:                    data.NcaId,
+		FunctionName:             data.FunctionName,
+		Status:                   types.StringValue(functionInfo.Status),
+		HelmChart:                data.HelmChart,
+		HelmChartServiceName:     data.HelmChartServiceName,
+		InferencePort:            data.InferencePort,
+		ContainerImage:           data.ContainerImage,
+		ContainerArgs:            data.ContainerArgs,
+		ContainerEnvironment:     data.ContainerEnvironment,
+		InferenceUrl:             data.InferenceUrl,
+		HealthUri:                data.HealthUri,
+		Health:                   data.Health,
+		APIBodyFormat:            data.APIBodyFormat,
+		DeploymentSpecifications: data.DeploymentSpecifications,
+		Tags:                     data.Tags,
+		Description:              data.Description,
+		Models:                   data.Models,
+		Resources:                data.Resources,
+		FunctionType:             data.FunctionType,
+		AuthorizedParties:        data.AuthorizedParties,
+	}
+}
+
+func artifactsComputedSchema() schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		Computed: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Artifact name",
+					Computed:            true,
+				},
+				"version": schema.StringAttribute{
+					MarkdownDescription: "Artifact version",
+					Computed:            true,
+				},
+				"uri": schema.StringAttribute{
+					MarkdownDescription: "Artifact URI",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+func cloudFunctionsDataSourceFunctionSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"function_id": schema.StringAttribute{
+				MarkdownDescription: "Function ID",
+				Computed:            true,
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Function Version ID",
+				Computed:            true,
+			},
+			"nca_id": schema.StringAttribute{
+				MarkdownDescription: "NCA ID",
+				Computed:            true,
+			},
+			"function_name": schema.StringAttribute{
+				MarkdownDescription: "Function name",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Function version status",
+				Computed:            true,
+			},
+			"helm_chart": schema.StringAttribute{
+				MarkdownDescription: "Helm chart registry uri",
+				Computed:            true,
+			},
+			"helm_chart_service_name": schema.StringAttribute{
+				MarkdownDescription: "Target service name",
+				Computed:            true,
+			},
+			"inference_port": schema.Int64Attribute{
+				MarkdownDescription: "Target port, will be service port or container port base on function-based",
+				Computed:            true,
+			},
+			"container_image": schema.StringAttribute{
+				MarkdownDescription: "Container image uri",
+				Computed:            true,
+			},
+			"container_args": schema.StringAttribute{
+				MarkdownDescription: "Args to be passed when launching the container",
+				Computed:            true,
+			},
+			"container_environment": schema.SetNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "Container environment key",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Container environment value",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"inference_url": schema.StringAttribute{
+				MarkdownDescription: "Service endpoint Path.",
+				Computed:            true,
+			},
+			"health_uri": schema.StringAttribute{
+				MarkdownDescription: "Service health endpoint Path.",
+				Computed:            true,
+			},
+			"health": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"protocol": schema.StringAttribute{
+						MarkdownDescription: "HTTP/gPRC protocol type for health endpoint",
+						Computed:            true,
+					},
+					"uri": schema.StringAttribute{
+						MarkdownDescription: "Health endpoint for the container or the helmChart",
+						Computed:            true,
+					},
+					"port": schema.Int64Attribute{
+						MarkdownDescription: "Port number where the health listener is running",
+						Computed:            true,
+					},
+					"timeout": schema.StringAttribute{
+						MarkdownDescription: "ISO 8601 duration string in PnDTnHnMn.nS format",
+						Computed:            true,
+					},
+					"expected_status_code": schema.Int64Attribute{
+						MarkdownDescription: "Expected return status code considered as successful",
+						Computed:            true,
+					},
+				},
+			},
+			"api_body_format": schema.StringAttribute{
+				MarkdownDescription: "API Body Format",
+				Computed:            true,
+			},
+			"deployment_specifications": schema.ListNestedAttribute{
+				MarkdownDescription: "Deployment specifications, only available for functions owned by the org",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"configuration": schema.StringAttribute{
+							MarkdownDescription: "Json definition overwriting the values.yaml file of Helm-Based Functions",
+							Computed:            true,
+						},
+						"backend": schema.StringAttribute{
+							MarkdownDescription: "NVCF Backend.",
+							Computed:            true,
+						},
+						"instance_type": schema.StringAttribute{
+							MarkdownDescription: "NVCF Backend Instance Type.",
+							Computed:            true,
+						},
+						"gpu_type": schema.StringAttribute{
+							MarkdownDescription: "GPU Type",
+							Computed:            true,
+						},
+						"max_instances": schema.Int64Attribute{
+							MarkdownDescription: "Max Instances Count",
+							Computed:            true,
+						},
+						"min_instances": schema.Int64Attribute{
+							MarkdownDescription: "Min Instances Count",
+							Computed:            true,
+						},
+						"max_request_concurrency": schema.Int64Attribute{
+							MarkdownDescription: "Max Concurrency Count",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Tags of the function.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the function",
+				Computed:            true,
+			},
+			"models":    artifactsComputedSchema(),
+			"resources": artifactsComputedSchema(),
+			"function_type": schema.StringAttribute{
+				MarkdownDescription: "Function type, \"DEFAULT\" or \"STREAMING\"",
+				Computed:            true,
+			},
+			"authorized_parties": schema.SetNestedAttribute{
+				MarkdownDescription: "Associated authorized parties, only available for functions owned by the org",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"nca_id": schema.StringAttribute{
+							MarkdownDescription: "NVIDIA Cloud Account authorized to invoke the function",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NvidiaCloudFunctionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_functions"
+}
+
+func (d *NvidiaCloudFunctionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Nvidia Cloud Functions Data Source. Lists the function versions visible to the org/team.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only return functions with this exact name",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return functions with a name matching this regular expression",
+				Optional:            true,
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Only return functions having all of these tags",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only return function versions in this status, such as \"ACTIVE\", \"DEPLOYING\", \"INACTIVE\" or \"ERROR\"",
+				Optional:            true,
+			},
+			"function_type": schema.StringAttribute{
+				MarkdownDescription: "Only return functions of this type, \"DEFAULT\" or \"STREAMING\"",
+				Optional:            true,
+			},
+			"visibility": schema.SetAttribute{
+				MarkdownDescription: "Only return functions with these visibilities: \"private\", \"public\" or \"shared\". Default is [\"private\", \"shared\"]",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"functions": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching function versions, one entry per version",
+				Computed:            true,
+				NestedObject:        cloudFunctionsDataSourceFunctionSchema(),
+			},
+		},
+	}
+}
+
+func (d *NvidiaCloudFunctionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = ngcClient.NVCFClient()
+}
+
+func matchCloudFunction(functionInfo utils.NvidiaCloudFunctionInfo, data NvidiaCloudFunctionsDataSourceModel, nameRegex *regexp.Regexp, tags []string) bool {
+	if data.Name.ValueString() != "" && functionInfo.Name != data.Name.ValueString() {
+		return false
+	}
+
+	if nameRegex != nil && !nameRegex.MatchString(functionInfo.Name) {
+		return false
+	}
+
+	if data.Status.ValueString() != "" && functionInfo.Status != data.Status.ValueString() {
+		return false
+	}
+
+	if data.FunctionType.ValueString() != "" && functionInfo.FunctionType != data.FunctionType.ValueString() {
+		return false
+	}
+
+	for _, tag := range tags {
+		if !slices.Contains(functionInfo.Tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *NvidiaCloudFunctionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NvidiaCloudFunctionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	visibility := []string{"private", "shared"}
+	if !data.Visibility.IsNull() {
+		visibility = make([]string, 0, len(data.Visibility.Elements()))
+		resp.Diagnostics.Append(data.Visibility.ElementsAs(ctx, &visibility, false)...)
+	}
+
+	apiVisibility := make([]string, 0, len(visibility))
+	for _, v := range visibility {
+		apiValue, ok := cloudFunctionVisibilities[v]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("visibility"),
+				"Invalid Visibility",
+				fmt.Sprintf("Expected one of \"private\", \"public\" or \"shared\". Got: %q", v),
+			)
+			continue
+		}
+		apiVisibility = append(apiVisibility, apiValue)
+	}
+
+	var nameRegex *regexp.Regexp
+	if data.NameRegex.ValueString() != "" {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Regular Expression",
+				err.Error(),
+			)
+		}
+	}
+
+	var tags []string
+	if !data.Tags.IsNull() {
+		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listNvidiaCloudFunctionsResponse, err := d.client.ListNvidiaCloudFunctions(ctx, apiVisibility)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to list Cloud Functions",
+			utils.ErrorDetail(err),
+		)
+		return
+	}
+
+	matchedFunctions := make([]utils.NvidiaCloudFunctionInfo, 0)
+	for _, f := range listNvidiaCloudFunctionsResponse.Functions {
+		if matchCloudFunction(f, data, nameRegex, tags) {
+			matchedFunctions = append(matchedFunctions, f)
+		}
+	}
+
+	sort.SliceStable(matchedFunctions, func(i, j int) bool {
+		if matchedFunctions[i].Name != matchedFunctions[j].Name {
+			return matchedFunctions[i].Name < matchedFunctions[j].Name
+		}
+		return matchedFunctions[i].CreatedAt.Before(matchedFunctions[j].CreatedAt)
+	})
+
+	functions := make([]NvidiaCloudFunctionsDataSourceFunctionModel, 0, len(matchedFunctions))
+	for i := range matchedFunctions {
+		functionInfo := &matchedFunctions[i]
+		var functionDeployment utils.NvidiaCloudFunctionDeployment
+		var functionAuthorizedParties []utils.AuthorizedParty
+
+		// Deployment and authorizations of public or shared functions can't be read by other accounts.
+		if !functionInfo.OwnedByDifferentAccount {
+			readNvidiaCloudFunctionDeploymentResponse, err := d.client.ReadNvidiaCloudFunctionDeployment(ctx, functionInfo.ID, functionInfo.VersionID)
+
+			if err != nil && !utils.IsNotFound(err) {
+				resp.Diagnostics.AddError(
+					fmt.Sprintf("Failed to read Cloud Function deployment %s", functionInfo.VersionID),
+					utils.ErrorDetail(err),
+				)
+				return
+			}
+
+			if err == nil {
+				functionDeployment = readNvidiaCloudFunctionDeploymentResponse.Deployment
+			}
+
+			getFunctionAuthorizationResponse, err := d.client.GetFunctionAuthorization(ctx, functionInfo.ID, functionInfo.VersionID)
+
+			if err != nil {
+				resp.Diagnostics.AddError(
+					fmt.Sprintf("Failed to read Cloud Function authorized parties %s", functionInfo.VersionID),
+					utils.ErrorDetail(err),
+				)
+				return
+			}
+			functionAuthorizedParties = getFunctionAuthorizationResponse.Function.AuthorizedParties
+		}
+
+		functions = append(functions, newNvidiaCloudFunctionsDataSourceFunctionModel(ctx, &resp.Diagnostics, functionInfo, &functionDeployment, functionAuthorizedParties))
+	}
+
+	functionsListType, functionsListTypeDiag := types.ListValueFrom(ctx, cloudFunctionsDataSourceFunctionSchema().Type(), functions)
+	resp.Diagnostics.Append(functionsListTypeDiag...)
+	data.Functions = functionsListType
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
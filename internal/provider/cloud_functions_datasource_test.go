@@ -0,0 +1,106 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build !unittest
+// +build !unittest
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/testutils"
+)
+
+var testCloudFunctionsDatasourceName = "terraform-cloud-functions-integ-datasource"
+var testCloudFunctionsDatasourceFullPath = fmt.Sprintf("data.ngc_cloud_functions.%s", testCloudFunctionsDatasourceName)
+
+func TestAccCloudFunctionsDataSource_FilterByName(t *testing.T) {
+
+	functionInfo := testutils.CreateHelmFunction(t)
+	defer testutils.DeleteFunction(t, functionInfo.Function.ID, functionInfo.Function.VersionID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+						data "ngc_cloud_functions" "%s" {
+						name = "%s"
+						tags = ["%s"]
+						}
+						`,
+					testCloudFunctionsDatasourceName, testutils.TestHelmFunctionName, testutils.TestTags[0]),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(testCloudFunctionsDatasourceFullPath, "functions.*", map[string]string{
+						"function_id":     functionInfo.Function.ID,
+						"version_id":      functionInfo.Function.VersionID,
+						"function_name":   testutils.TestHelmFunctionName,
+						"helm_chart":      testutils.TestHelmUri,
+						"inference_url":   testutils.TestHelmInferenceUrl,
+						"api_body_format": testutils.TestHelmAPIFormat,
+						"nca_id":          testutils.TestNcaID,
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionsDataSource_NoMatch(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+						data "ngc_cloud_functions" "%s" {
+						name_regex = "^terraform-no-such-function-[0-9]{32}$"
+						}
+						`,
+					testCloudFunctionsDatasourceName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionsDatasourceFullPath, "functions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionsDataSource_InvalidFilters(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+						data "ngc_cloud_functions" "%s" {
+						visibility = ["everyone"]
+						}
+						`,
+					testCloudFunctionsDatasourceName),
+				ExpectError: regexp.MustCompile("Invalid Visibility"),
+			},
+			{
+				Config: fmt.Sprintf(`
+						data "ngc_cloud_functions" "%s" {
+						name_regex = "("
+						}
+						`,
+					testCloudFunctionsDatasourceName),
+				ExpectError: regexp.MustCompile("Invalid Name Regular Expression"),
+			},
+		},
+	})
+}
@@ -0,0 +1,205 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ basetypes.StringTypable                    = DeploymentConfigurationType{}
+	_ basetypes.StringValuableWithSemanticEquals = DeploymentConfigurationValue{}
+	_ xattr.ValidateableAttribute                = DeploymentConfigurationValue{}
+)
+
+// DeploymentConfigurationType is the type of a deployment configuration, a JSON or YAML document
+// such as Helm values, compared by its content rather than by its text.
+type DeploymentConfigurationType struct {
+	basetypes.StringType
+}
+
+func (t DeploymentConfigurationType) String() string {
+	return "DeploymentConfigurationType"
+}
+
+func (t DeploymentConfigurationType) ValueType(ctx context.Context) attr.Value {
+	return DeploymentConfigurationValue{}
+}
+
+func (t DeploymentConfigurationType) Equal(o attr.Type) bool {
+	other, ok := o.(DeploymentConfigurationType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t DeploymentConfigurationType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DeploymentConfigurationValue{StringValue: in}, nil
+}
+
+func (t DeploymentConfigurationType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// DeploymentConfigurationValue is a deployment configuration, see DeploymentConfigurationType.
+type DeploymentConfigurationValue struct {
+	basetypes.StringValue
+}
+
+func NewDeploymentConfigurationNull() DeploymentConfigurationValue {
+	return DeploymentConfigurationValue{StringValue: basetypes.NewStringNull()}
+}
+
+func NewDeploymentConfigurationValue(value string) DeploymentConfigurationValue {
+	return DeploymentConfigurationValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v DeploymentConfigurationValue) Type(ctx context.Context) attr.Type {
+	return DeploymentConfigurationType{}
+}
+
+func (v DeploymentConfigurationValue) Equal(o attr.Value) bool {
+	other, ok := o.(DeploymentConfigurationValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both configurations decode to the same values, whatever their
+// format, whitespace or key order, so the configuration read back from NVCF doesn't show a diff.
+func (v DeploymentConfigurationValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(DeploymentConfigurationValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	current, err := v.Unmarshal()
+	if err != nil {
+		return false, diags
+	}
+
+	updated, err := newValue.Unmarshal()
+	if err != nil {
+		return false, diags
+	}
+
+	return reflect.DeepEqual(current, updated), diags
+}
+
+func (v DeploymentConfigurationValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := v.Unmarshal(); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid deployment configuration",
+			fmt.Sprintf("configuration must be a JSON object or a YAML mapping: %s", err),
+		)
+	}
+}
+
+// Unmarshal decodes the JSON or YAML configuration into the JSON values sent to NVCF.
+// An empty configuration decodes to nil, any other document must be an object, such as Helm values.
+func (v DeploymentConfigurationValue) Unmarshal() (interface{}, error) {
+	value := v.ValueString()
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	configuration, err := unmarshalConfiguration(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// A YAML document may be any scalar, such as "foo" or "42", which NVCF would only reject on deployment.
+	if _, ok := configuration.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("got %s", configurationKind(configuration))
+	}
+	return configuration, nil
+}
+
+// unmarshalConfiguration decodes a JSON document, or a YAML one when it is not JSON.
+func unmarshalConfiguration(value string) (interface{}, error) {
+	var configuration interface{}
+
+	if err := json.Unmarshal([]byte(value), &configuration); err == nil {
+		return configuration, nil
+	}
+
+	var yamlConfiguration interface{}
+	if err := yaml.Unmarshal([]byte(value), &yamlConfiguration); err != nil {
+		return nil, err
+	}
+
+	// YAML is decoded through JSON for both formats to give the same values, such as float64 numbers.
+	jsonConfiguration, err := json.Marshal(yamlConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(jsonConfiguration, &configuration); err != nil {
+		return nil, err
+	}
+	return configuration, nil
+}
+
+// configurationKind describes the kind of a decoded configuration for a diagnostic.
+func configurationKind(configuration interface{}) string {
+	switch configuration.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case []interface{}:
+		return "a list"
+	default:
+		return fmt.Sprintf("a %T", configuration)
+	}
+}
@@ -0,0 +1,206 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentConfigurationValue_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	image := map[string]interface{}{
+		"image":    map[string]interface{}{"repository": "nvcr.io/org/echo", "tag": "0.3"},
+		"replicas": float64(2),
+	}
+
+	tests := []struct {
+		name      string
+		value     DeploymentConfigurationValue
+		want      interface{}
+		wantError bool
+	}{
+		{
+			name:  "Null",
+			value: NewDeploymentConfigurationNull(),
+			want:  nil,
+		},
+		{
+			name:  "Empty",
+			value: NewDeploymentConfigurationValue(" \n"),
+			want:  nil,
+		},
+		{
+			name:  "JSON",
+			value: NewDeploymentConfigurationValue(`{"replicas": 2, "image": {"tag": "0.3", "repository": "nvcr.io/org/echo"}}`),
+			want:  image,
+		},
+		{
+			name:  "YAML",
+			value: NewDeploymentConfigurationValue("image:\n  repository: nvcr.io/org/echo\n  tag: \"0.3\"\nreplicas: 2\n"),
+			want:  image,
+		},
+		{
+			name:      "Invalid",
+			value:     NewDeploymentConfigurationValue(`{"replicas": 2`),
+			wantError: true,
+		},
+		{
+			name:      "YAMLString",
+			value:     NewDeploymentConfigurationValue("foo"),
+			wantError: true,
+		},
+		{
+			name:      "JSONNumber",
+			value:     NewDeploymentConfigurationValue("42"),
+			wantError: true,
+		},
+		{
+			name:      "JSONList",
+			value:     NewDeploymentConfigurationValue(`[{"replicas": 2}]`),
+			wantError: true,
+		},
+		{
+			name:      "YAMLNull",
+			value:     NewDeploymentConfigurationValue("~"),
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.value.Unmarshal()
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeploymentConfigurationValue_StringSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		current  DeploymentConfigurationValue
+		updated  DeploymentConfigurationValue
+		want     bool
+		wantDiag bool
+	}{
+		{
+			name:    "Whitespace",
+			current: NewDeploymentConfigurationValue(`{"replicas":2}`),
+			updated: NewDeploymentConfigurationValue("{\n  \"replicas\": 2\n}"),
+			want:    true,
+		},
+		{
+			name:    "KeyOrder",
+			current: NewDeploymentConfigurationValue(`{"a":1,"b":2}`),
+			updated: NewDeploymentConfigurationValue(`{"b":2,"a":1}`),
+			want:    true,
+		},
+		{
+			name:    "YAMLAndJSON",
+			current: NewDeploymentConfigurationValue(`{"image":{"tag":"0.3"}}`),
+			updated: NewDeploymentConfigurationValue("image:\n  tag: \"0.3\"\n"),
+			want:    true,
+		},
+		{
+			name:    "DifferentContent",
+			current: NewDeploymentConfigurationValue(`{"replicas":2}`),
+			updated: NewDeploymentConfigurationValue(`{"replicas":3}`),
+			want:    false,
+		},
+		{
+			name:    "Invalid",
+			current: NewDeploymentConfigurationValue(`{"replicas":2}`),
+			updated: NewDeploymentConfigurationValue(`{"replicas"`),
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := tt.current.StringSemanticEquals(context.Background(), tt.updated)
+			assert.False(t, diags.HasError(), "StringSemanticEquals() diagnostics = %v", diags)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, diags := NewDeploymentConfigurationValue("{}").StringSemanticEquals(context.Background(), types.StringValue("{}"))
+	assert.True(t, diags.HasError())
+}
+
+func TestDeploymentConfigurationValue_ValidateAttribute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     DeploymentConfigurationValue
+		wantError bool
+	}{
+		{
+			name:  "Null",
+			value: NewDeploymentConfigurationNull(),
+		},
+		{
+			name:  "Unknown",
+			value: DeploymentConfigurationValue{StringValue: types.StringUnknown()},
+		},
+		{
+			name:  "YAML",
+			value: NewDeploymentConfigurationValue("replicas: 2\n"),
+		},
+		{
+			name:      "Invalid",
+			value:     NewDeploymentConfigurationValue("replicas: [2\n"),
+			wantError: true,
+		},
+		{
+			name:      "String",
+			value:     NewDeploymentConfigurationValue("foo"),
+			wantError: true,
+		},
+		{
+			name:      "Number",
+			value:     NewDeploymentConfigurationValue("42"),
+			wantError: true,
+		},
+		{
+			name:      "Boolean",
+			value:     NewDeploymentConfigurationValue("true"),
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var resp xattr.ValidateAttributeResponse
+			tt.value.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("configuration")}, &resp)
+			assert.Equal(t, tt.wantError, resp.Diagnostics.HasError(), "ValidateAttribute() diagnostics = %v", resp.Diagnostics)
+		})
+	}
+}
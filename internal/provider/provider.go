@@ -12,21 +12,30 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
 )
 
 // Ensure NgcProvider satisfies various provider interfaces.
 var _ provider.Provider = &NgcProvider{}
 var _ provider.ProviderWithFunctions = &NgcProvider{}
+var _ provider.ProviderWithEphemeralResources = &NgcProvider{}
 
 // NgcProvider defines the provider implementation.
 type NgcProvider struct {
@@ -38,12 +47,40 @@ type NgcProvider struct {
 
 // NgcProviderModel describes the provider data model.
 type NgcProviderModel struct {
-	NgcEndpoint types.String `tfsdk:"ngc_endpoint"`
-	NgcApiKey   types.String `tfsdk:"ngc_api_key"`
-	NgcOrg      types.String `tfsdk:"ngc_org"`
-	NgcTeam     types.String `tfsdk:"ngc_team"`
+	NgcEndpoint            types.String          `tfsdk:"ngc_endpoint"`
+	NgcApiKey              types.String          `tfsdk:"ngc_api_key"`
+	NgcApiKeyFile          types.String          `tfsdk:"ngc_api_key_file"`
+	NgcApiKeyCommand       types.String          `tfsdk:"ngc_api_key_command"`
+	NgcOrg                 types.String          `tfsdk:"ngc_org"`
+	NgcTeam                types.String          `tfsdk:"ngc_team"`
+	Profile                types.String          `tfsdk:"profile"`
+	MaxRetries             types.Int64           `tfsdk:"max_retries"`
+	RetryWaitMin           types.String          `tfsdk:"retry_wait_min"`
+	RetryWaitMax           types.String          `tfsdk:"retry_wait_max"`
+	DeploymentPollInterval types.String          `tfsdk:"deployment_poll_interval"`
+	RequestsPerSecond      types.Float64         `tfsdk:"requests_per_second"`
+	Burst                  types.Int64           `tfsdk:"burst"`
+	RedactedJSONPaths      types.List            `tfsdk:"redacted_json_paths"`
+	ProxyUrl               types.String          `tfsdk:"proxy_url"`
+	CACertFile             types.String          `tfsdk:"ca_cert_file"`
+	CACertPEM              types.String          `tfsdk:"ca_cert_pem"`
+	ClientCert             types.String          `tfsdk:"client_cert"`
+	ClientKey              types.String          `tfsdk:"client_key"`
+	InsecureSkipVerify     types.Bool            `tfsdk:"insecure_skip_verify"`
+	Auth                   *NgcProviderAuthModel `tfsdk:"auth"`
 }
 
+// NgcProviderAuthModel describes the auth block, used in place of an NGC personal key.
+type NgcProviderAuthModel struct {
+	Type         types.String `tfsdk:"type"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	TokenURL     types.String `tfsdk:"token_url"`
+	Scopes       types.List   `tfsdk:"scopes"`
+}
+
+const authTypeClientCredentials = "client_credentials"
+
 func (p *NgcProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "ngc"
 	resp.Version = p.version
@@ -61,6 +98,16 @@ func (p *NgcProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"ngc_api_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file holding the NGC Personal Token, read again after a 401. Conflicts with `ngc_api_key` and `ngc_api_key_command`. Can also be set with the `NGC_API_KEY_FILE` environment variable.",
+				Optional:            true,
+			},
+			"ngc_api_key_command": schema.StringAttribute{
+				MarkdownDescription: "Shell command printing the NGC Personal Token on its standard output, such as a Vault, 1Password CLI or SSO helper. " +
+					"The output is either the key itself or a JSON object `{\"api_key\": \"...\", \"expires_at\": \"2024-06-01T12:00:00Z\"}`. " +
+					"The command runs again when the key is about to expire or after a 401. Conflicts with `ngc_api_key` and `ngc_api_key_file`. Can also be set with the `NGC_API_KEY_COMMAND` environment variable.",
+				Optional: true,
+			},
 			"ngc_org": schema.StringAttribute{
 				MarkdownDescription: "NGC Org Name.",
 				Optional:            true,
@@ -69,33 +116,143 @@ func (p *NgcProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				MarkdownDescription: "NGC Team Name",
 				Optional:            true,
 			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Profile of the NGC CLI config file, `$NGC_CLI_HOME/config` or `~/.ngc/config`, to read the API key, org and team from. Can also be set with the `NGC_CLI_PROFILE` environment variable. Default is the `CURRENT` profile written by `ngc config set`, ignored when the file doesn't exist, can't be parsed or has no `CURRENT` profile. The provider configuration and the environment variables take precedence over the profile.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries for NGC API requests failed with a transient error, such as 429, 502, 503, 504 or a connection reset. Requests which are not idempotent, such as the creation of a function version, are only retried on 429 or when they were not sent. Default is 4. Set to 0 to disable retries.",
+				Optional:            true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "Backoff before the first retry, doubled on every following retry. Must be a Go duration string, such as \"1s\". Default is \"1s\".",
+				Optional:            true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "Maximum backoff between two retries. A `Retry-After` header returned by the API takes precedence, up to 5 minutes. Must be a Go duration string, such as \"30s\". Default is \"30s\".",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum rate of NGC API requests, shared by every resource and data source of the provider configuration. The rate is halved after a 429 and recovers as requests succeed, and requests are paused until the rate limit resets when the API tells when, up to 5 minutes. Default is 10. Set to 0 to disable rate limiting.",
+				Optional:            true,
+			},
+			"burst": schema.Int64Attribute{
+				MarkdownDescription: "Number of NGC API requests which can be sent at once before being limited to `requests_per_second`. Default is 10.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "Proxy used to reach the NGC API, such as \"http://proxy.example.com:3128\". Can also be set with the `NGC_PROXY_URL` environment variable. Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded CA bundle trusted in addition to the system certificates, for TLS-intercepting proxies or private NGC endpoints. Can also be set with the `NGC_CA_CERT_FILE` environment variable.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA bundle trusted in addition to the system certificates. Can also be set with the `NGC_CA_CERT_PEM` environment variable.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate, or the path to it, for mTLS. Requires `client_key`. Can also be set with the `NGC_CLIENT_CERT` environment variable.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client private key, or the path to it, for mTLS. Requires `client_cert`. Can also be set with the `NGC_CLIENT_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification of the NGC API server certificate. Only meant for testing. Can also be set with the `NGC_INSECURE_SKIP_VERIFY` environment variable.",
+				Optional:            true,
+			},
+			"redacted_json_paths": schema.ListAttribute{
+				MarkdownDescription: "Additional request and response body fields redacted from the debug logs, `secrets[].value` is always redacted. Keys are separated by dots and a `[]` suffix walks every element of an array, such as `containerEnvironment[].value`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"deployment_poll_interval": schema.StringAttribute{
+				MarkdownDescription: "Wait between the first two polls of a function deployment status, doubled on every following poll up to 60s or the interval itself if greater. Must be a Go duration string, such as \"10s\". Default is \"5s\".",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"auth": schema.SingleNestedBlock{
+				MarkdownDescription: "Authenticate with short-lived access tokens of a service account instead of an NGC personal key. " +
+					"The tokens are obtained from `token_url` and renewed before their expiry or after a 401. Conflicts with `ngc_api_key`, `ngc_api_key_file` and `ngc_api_key_command`.",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Authentication flow. Only `client_credentials`, the OAuth2 client credentials grant, is supported.",
+						Optional:            true,
+					},
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "Service account client ID. Can also be set with the `NGC_AUTH_CLIENT_ID` environment variable.",
+						Optional:            true,
+					},
+					"client_secret": schema.StringAttribute{
+						MarkdownDescription: "Service account client secret. Can also be set with the `NGC_AUTH_CLIENT_SECRET` environment variable.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"token_url": schema.StringAttribute{
+						MarkdownDescription: "Token endpoint of the service account issuer, such as \"https://<issuer-id>.ssa.nvidia.com/token\". Can also be set with the `NGC_AUTH_TOKEN_URL` environment variable.",
+						Optional:            true,
+					},
+					"scopes": schema.ListAttribute{
+						MarkdownDescription: "Scopes requested for the access tokens, such as `[\"list_functions\", \"deploy_function\"]`.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
 
 func (p *NgcProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
-	ngcEndpoint := os.Getenv("NGC_ENDPOINT")
-	ngcApiKey := os.Getenv("NGC_API_KEY")
-	ngcOrg := os.Getenv("NGC_ORG")
-	ngcTeam := os.Getenv("NGC_TEAM")
-
 	var data NgcProviderModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
-	// Check configuration data, which should take precedence over
-	// environment variable data, if found.
-	if data.NgcApiKey.ValueString() != "" {
-		ngcApiKey = data.NgcApiKey.ValueString()
+	// The precedence order is the configuration data, then the environment
+	// variables, then the NGC CLI profile.
+	profile := p.loadNGCCLIProfile(ctx, data, &resp.Diagnostics)
+
+	ngcEndpoint := os.Getenv("NGC_ENDPOINT")
+	ngcOrg := firstNonEmpty(os.Getenv("NGC_ORG"), profile.Org)
+	ngcTeam := firstNonEmpty(os.Getenv("NGC_TEAM"), profile.Team)
+
+	var ngcApiKey string
+	var tokenSource utils.TokenSource
+	var clientCredentials *clientCredentialsConfig
+
+	if data.Auth != nil {
+		clientCredentials = authClientCredentialsConfig(ctx, data, &resp.Diagnostics)
+	} else {
+		tokenSource = apiKeyTokenSource(data, profile, &resp.Diagnostics)
 	}
 
-	if ngcApiKey == "" {
-		resp.Diagnostics.AddError(
-			"Missing NGC_API_KEY Configuration",
-			"While configuring the provider, the NGC personal key was not found in "+
-				"the NGC_API_KEY environment variable or provider "+
-				"configuration block ngc_api_key attribute.",
-		)
+	switch tokenSource := tokenSource.(type) {
+	case nil:
+		if clientCredentials == nil && !resp.Diagnostics.HasError() {
+			resp.Diagnostics.AddError(
+				"Missing NGC_API_KEY Configuration",
+				"While configuring the provider, the NGC personal key was not found in "+
+					"the NGC_API_KEY, NGC_API_KEY_FILE or NGC_API_KEY_COMMAND environment variables, provider "+
+					"configuration block ngc_api_key, ngc_api_key_file or ngc_api_key_command attributes or NGC CLI profile.",
+			)
+		}
+	case utils.StaticTokenSource:
+		ngcApiKey = string(tokenSource)
+	default:
+		// Fail early on a broken file or command rather than on the first API request.
+		apiKey, err := tokenSource.Token(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Obtain NGC API Key",
+				fmt.Sprintf("While configuring the provider, the NGC personal key could not be obtained: %s", err),
+			)
+		}
+		ngcApiKey = apiKey
 	}
 
 	if data.NgcOrg.ValueString() != "" {
@@ -106,8 +263,8 @@ func (p *NgcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		resp.Diagnostics.AddError(
 			"Missing NGC_ORG Configuration",
 			"While configuring the provider, the NGC Org Name was not found in "+
-				"the NGC_ORG environment variable or provider "+
-				"configuration block ngc_org attribute.",
+				"the NGC_ORG environment variable, provider "+
+				"configuration block ngc_org attribute or NGC CLI profile.",
 		)
 	}
 
@@ -123,32 +280,374 @@ func (p *NgcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		ngcEndpoint = "https://api.ngc.nvidia.com"
 	}
 
+	retryPolicy := utils.DefaultRetryPolicy()
+
+	if !data.MaxRetries.IsNull() {
+		if data.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid max_retries Configuration",
+				"The max_retries attribute must not be negative.",
+			)
+		}
+		retryPolicy.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	if data.RetryWaitMin.ValueString() != "" {
+		retryPolicy.RetryWaitMin = parseDurationAttribute(path.Root("retry_wait_min"), data.RetryWaitMin.ValueString(), &resp.Diagnostics)
+	}
+
+	if data.RetryWaitMax.ValueString() != "" {
+		retryPolicy.RetryWaitMax = parseDurationAttribute(path.Root("retry_wait_max"), data.RetryWaitMax.ValueString(), &resp.Diagnostics)
+	}
+
+	if retryPolicy.RetryWaitMin > retryPolicy.RetryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid retry_wait_min Configuration",
+			fmt.Sprintf("The retry_wait_min %s must not be greater than retry_wait_max %s.", retryPolicy.RetryWaitMin, retryPolicy.RetryWaitMax),
+		)
+	}
+
+	deploymentPollPolicy := utils.DefaultDeploymentPollPolicy()
+
+	if data.DeploymentPollInterval.ValueString() != "" {
+		deploymentPollPolicy.Interval = parseDurationAttribute(path.Root("deployment_poll_interval"), data.DeploymentPollInterval.ValueString(), &resp.Diagnostics)
+		deploymentPollPolicy.MaxInterval = max(deploymentPollPolicy.MaxInterval, deploymentPollPolicy.Interval)
+	}
+
+	requestsPerSecond := float64(utils.DefaultRequestsPerSecond)
+	burst := int64(utils.DefaultBurst)
+
+	if !data.RequestsPerSecond.IsNull() {
+		requestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+		if requestsPerSecond < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid requests_per_second Configuration",
+				"The requests_per_second attribute must not be negative.",
+			)
+		}
+	}
+
+	if !data.Burst.IsNull() {
+		burst = data.Burst.ValueInt64()
+		if burst < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("burst"),
+				"Invalid burst Configuration",
+				"The burst attribute must be at least 1.",
+			)
+		}
+	}
+
+	var rateLimiter *utils.RateLimiter
+	if requestsPerSecond > 0 {
+		rateLimiter = utils.NewRateLimiter(requestsPerSecond, int(burst))
+	}
+
+	var redactedJSONPaths []string
+	if !data.RedactedJSONPaths.IsNull() {
+		resp.Diagnostics.Append(data.RedactedJSONPaths.ElementsAs(ctx, &redactedJSONPaths, false)...)
+	}
+
+	httpClientConfig := utils.HTTPClientConfig{
+		ProxyURL:   os.Getenv("NGC_PROXY_URL"),
+		CACertFile: os.Getenv("NGC_CA_CERT_FILE"),
+		CACertPEM:  os.Getenv("NGC_CA_CERT_PEM"),
+		ClientCert: os.Getenv("NGC_CLIENT_CERT"),
+		ClientKey:  os.Getenv("NGC_CLIENT_KEY"),
+	}
+
+	if v := os.Getenv("NGC_INSECURE_SKIP_VERIFY"); v != "" {
+		insecureSkipVerify, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid NGC_INSECURE_SKIP_VERIFY Configuration",
+				fmt.Sprintf("Expected a boolean value, such as \"true\" or \"false\". Got: %q", v),
+			)
+		}
+		httpClientConfig.InsecureSkipVerify = insecureSkipVerify
+	}
+
+	if data.ProxyUrl.ValueString() != "" {
+		httpClientConfig.ProxyURL = data.ProxyUrl.ValueString()
+	}
+
+	if data.CACertFile.ValueString() != "" {
+		httpClientConfig.CACertFile = data.CACertFile.ValueString()
+	}
+
+	if data.CACertPEM.ValueString() != "" {
+		httpClientConfig.CACertPEM = data.CACertPEM.ValueString()
+	}
+
+	if data.ClientCert.ValueString() != "" {
+		httpClientConfig.ClientCert = data.ClientCert.ValueString()
+	}
+
+	if data.ClientKey.ValueString() != "" {
+		httpClientConfig.ClientKey = data.ClientKey.ValueString()
+	}
+
+	if !data.InsecureSkipVerify.IsNull() {
+		httpClientConfig.InsecureSkipVerify = data.InsecureSkipVerify.ValueBool()
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	httpClient := cleanhttp.DefaultPooledClient()
+	httpClient, err := utils.NewHTTPClient(httpClientConfig)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid HTTP Client Configuration",
+			fmt.Sprintf("While configuring the provider, the proxy or TLS settings could not be applied: %s", err),
+		)
+		return
+	}
+
+	httpClient.Transport = utils.NewTransport(httpClient.Transport, p.version, redactedJSONPaths)
+
+	if clientCredentials != nil {
+		// The token endpoint is reached through the same proxy and TLS settings as the NGC API.
+		tokenSource = utils.NewClientCredentialsTokenSource(httpClient, clientCredentials.TokenURL, clientCredentials.ClientID, clientCredentials.ClientSecret, clientCredentials.Scopes)
+
+		accessToken, err := tokenSource.Token(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Obtain NGC Access Token",
+				fmt.Sprintf("While configuring the provider, the access token could not be obtained from %s: %s", clientCredentials.TokenURL, err),
+			)
+			return
+		}
+		ngcApiKey = accessToken
+	}
 
 	client := &utils.NGCClient{
-		NgcEndpoint: ngcEndpoint,
-		NgcApiKey:   ngcApiKey,
-		NgcOrg:      ngcOrg,
-		NgcTeam:     ngcTeam,
-		HttpClient:  httpClient,
+		NgcEndpoint:          ngcEndpoint,
+		NgcApiKey:            ngcApiKey,
+		TokenSource:          renewableTokenSource(tokenSource),
+		NgcOrg:               ngcOrg,
+		NgcTeam:              ngcTeam,
+		HttpClient:           httpClient,
+		RetryPolicy:          retryPolicy,
+		DeploymentPollPolicy: deploymentPollPolicy,
+		RateLimiter:          rateLimiter,
 	}
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+// apiKeyTokenSource returns where the API key comes from: the configuration
+// data, then the environment variables, then the NGC CLI profile. At each
+// level, the key itself takes precedence over the key file and the key command.
+func apiKeyTokenSource(data NgcProviderModel, profile utils.NGCCLIProfile, diags *diag.Diagnostics) utils.TokenSource {
+	configured := make([]string, 0)
+	for name, v := range map[string]types.String{
+		"ngc_api_key":         data.NgcApiKey,
+		"ngc_api_key_file":    data.NgcApiKeyFile,
+		"ngc_api_key_command": data.NgcApiKeyCommand,
+	} {
+		if v.ValueString() != "" {
+			configured = append(configured, name)
+		}
+	}
+
+	if len(configured) > 1 {
+		sort.Strings(configured)
+		diags.AddError(
+			"Conflicting NGC API Key Configuration",
+			fmt.Sprintf("Only one of ngc_api_key, ngc_api_key_file and ngc_api_key_command can be set. Got: %s", strings.Join(configured, ", ")),
+		)
+		return nil
+	}
+
+	switch {
+	case data.NgcApiKey.ValueString() != "":
+		return utils.StaticTokenSource(data.NgcApiKey.ValueString())
+	case data.NgcApiKeyFile.ValueString() != "":
+		return utils.NewFileTokenSource(data.NgcApiKeyFile.ValueString())
+	case data.NgcApiKeyCommand.ValueString() != "":
+		return utils.NewCommandTokenSource(data.NgcApiKeyCommand.ValueString())
+	case os.Getenv("NGC_API_KEY") != "":
+		return utils.StaticTokenSource(os.Getenv("NGC_API_KEY"))
+	case os.Getenv("NGC_API_KEY_FILE") != "":
+		return utils.NewFileTokenSource(os.Getenv("NGC_API_KEY_FILE"))
+	case os.Getenv("NGC_API_KEY_COMMAND") != "":
+		return utils.NewCommandTokenSource(os.Getenv("NGC_API_KEY_COMMAND"))
+	case profile.ApiKey != "":
+		return utils.StaticTokenSource(profile.ApiKey)
+	}
+
+	return nil
+}
+
+// clientCredentialsConfig holds the resolved settings of an auth block of type client_credentials.
+type clientCredentialsConfig struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// authClientCredentialsConfig validates the auth block and resolves its
+// settings, the configuration data taking precedence over the environment variables.
+func authClientCredentialsConfig(ctx context.Context, data NgcProviderModel, diags *diag.Diagnostics) *clientCredentialsConfig {
+	auth := data.Auth
+
+	for name, v := range map[string]types.String{
+		"ngc_api_key":         data.NgcApiKey,
+		"ngc_api_key_file":    data.NgcApiKeyFile,
+		"ngc_api_key_command": data.NgcApiKeyCommand,
+	} {
+		if v.ValueString() != "" {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Conflicting NGC API Key Configuration",
+				fmt.Sprintf("The %s attribute cannot be set together with the auth block.", name),
+			)
+		}
+	}
+
+	if auth.Type.ValueString() != authTypeClientCredentials {
+		diags.AddAttributeError(
+			path.Root("auth").AtName("type"),
+			"Invalid auth Configuration",
+			fmt.Sprintf("The auth type must be %q. Got: %q", authTypeClientCredentials, auth.Type.ValueString()),
+		)
+	}
+
+	config := &clientCredentialsConfig{
+		TokenURL:     firstNonEmpty(auth.TokenURL.ValueString(), os.Getenv("NGC_AUTH_TOKEN_URL")),
+		ClientID:     firstNonEmpty(auth.ClientID.ValueString(), os.Getenv("NGC_AUTH_CLIENT_ID")),
+		ClientSecret: firstNonEmpty(auth.ClientSecret.ValueString(), os.Getenv("NGC_AUTH_CLIENT_SECRET")),
+	}
+
+	for _, setting := range []struct {
+		name  string
+		env   string
+		value string
+	}{
+		{name: "client_id", env: "NGC_AUTH_CLIENT_ID", value: config.ClientID},
+		{name: "client_secret", env: "NGC_AUTH_CLIENT_SECRET", value: config.ClientSecret},
+		{name: "token_url", env: "NGC_AUTH_TOKEN_URL", value: config.TokenURL},
+	} {
+		if setting.value == "" {
+			diags.AddAttributeError(
+				path.Root("auth").AtName(setting.name),
+				"Missing auth Configuration",
+				fmt.Sprintf("While configuring the provider, the %s was not found in the %s environment variable or auth block %s attribute.", setting.name, setting.env, setting.name),
+			)
+		}
+	}
+
+	if !auth.Scopes.IsNull() {
+		diags.Append(auth.Scopes.ElementsAs(ctx, &config.Scopes, false)...)
+	}
+
+	return config
+}
+
+// renewableTokenSource drops the static token sources, whose key is set on the client as is.
+func renewableTokenSource(tokenSource utils.TokenSource) utils.TokenSource {
+	if _, ok := tokenSource.(utils.StaticTokenSource); ok {
+		return nil
+	}
+	return tokenSource
+}
+
+// loadNGCCLIProfile reads the profile selected by the profile attribute or the
+// NGC_CLI_PROFILE environment variable from the NGC CLI config file. When no
+// profile is explicitly selected, the file may be missing, malformed or
+// without a CURRENT profile, as the settings may all be configured otherwise.
+func (p *NgcProvider) loadNGCCLIProfile(ctx context.Context, data NgcProviderModel, diags *diag.Diagnostics) utils.NGCCLIProfile {
+	profileName := os.Getenv("NGC_CLI_PROFILE")
+	if data.Profile.ValueString() != "" {
+		profileName = data.Profile.ValueString()
+	}
+
+	explicit := profileName != ""
+	if !explicit {
+		profileName = utils.DefaultNGCCLIProfile
+	}
+
+	configPath, err := utils.DefaultNGCCLIConfigPath()
+	if err != nil {
+		if explicit {
+			diags.AddAttributeError(
+				path.Root("profile"),
+				"Failed to locate NGC CLI Config",
+				fmt.Sprintf("While configuring the provider, the NGC CLI config file could not be located: %s", err),
+			)
+		}
+		return utils.NGCCLIProfile{}
+	}
+
+	profile, err := utils.LoadNGCCLIProfile(configPath, profileName)
+
+	if err != nil {
+		if !explicit {
+			if !utils.IsNGCCLIConfigNotFound(err) {
+				tflog.Warn(ctx, fmt.Sprintf("ignoring the NGC CLI profile %q which could not be read: %s", profileName, err))
+			}
+			return utils.NGCCLIProfile{}
+		}
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Invalid NGC CLI Config",
+			fmt.Sprintf("While configuring the provider, the NGC CLI profile %q could not be read: %s", profileName, err),
+		)
+		return utils.NGCCLIProfile{}
+	}
+
+	return *profile
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func parseDurationAttribute(attributePath path.Path, value string, diags *diag.Diagnostics) time.Duration {
+	duration, err := time.ParseDuration(value)
+
+	if err != nil || duration < 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Duration Configuration",
+			fmt.Sprintf("Expected a non-negative duration string, such as \"30s\" or \"2m\". Got: %q", value),
+		)
+		return 0
+	}
+
+	return duration
+}
+
 func (p *NgcProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewNvidiaCloudFunctionResource,
+		NewNvidiaCloudFunctionDeploymentResource,
+		NewNvidiaCloudFunctionAuthorizationResource,
+		NewNvidiaCloudFunctionFamilyResource,
+	}
+}
+
+func (p *NgcProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewNvidiaCloudFunctionSecretEphemeralResource,
 	}
 }
 
 func (p *NgcProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewNvidiaCloudFunctionDataSource,
+		NewNvidiaCloudFunctionsDataSource,
 	}
 }
 
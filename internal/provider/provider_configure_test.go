@@ -0,0 +1,498 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+const (
+	testConfigureFunctionID = "4c9d4b3f-41e4-4b5a-8b59-0d1f4b6e2c11"
+	testConfigureVersionID  = "9a1f7f3e-2a4b-4d2f-9c39-7a4b1f0d8e22"
+)
+
+// configureTestProvider configures a new provider instance with the given
+// attributes, the other attributes being null, and returns its client.
+func configureTestProvider(t *testing.T, attributes map[string]tftypes.Value) *utils.NGCClient {
+	t.Helper()
+
+	client, diags := configureTestProviderWithDiagnostics(t, attributes)
+	if diags.HasError() {
+		t.Fatalf("NgcProvider.Configure() diagnostics = %v", diags)
+	}
+	return client
+}
+
+func configureTestProviderWithDiagnostics(t *testing.T, attributes map[string]tftypes.Value) (*utils.NGCClient, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if v, ok := attributes[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	configureResp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Raw:    tftypes.NewValue(objectType, values),
+			Schema: schemaResp.Schema,
+		},
+	}, configureResp)
+
+	client, _ := configureResp.ResourceData.(*utils.NGCClient)
+	return client, configureResp.Diagnostics
+}
+
+// newConfigureTestServer serves a function version named after the org, to the given org and API key only.
+func newConfigureTestServer(t *testing.T, org string, apiKey string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+apiKey || !strings.HasPrefix(r.URL.Path, "/v2/orgs/"+org+"/") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"function": {"id": "%s", "versionId": "%s", "name": "%s-function"}}`, testConfigureFunctionID, testConfigureVersionID, org)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNgcProvider_ConfigureAliases(t *testing.T) {
+	t.Parallel()
+
+	stagingServer := newConfigureTestServer(t, "staging-org", "staging-key")
+	prodServer := newConfigureTestServer(t, "prod-org", "prod-key")
+
+	staging := configureTestProvider(t, map[string]tftypes.Value{
+		"ngc_endpoint": tftypes.NewValue(tftypes.String, stagingServer.URL),
+		"ngc_api_key":  tftypes.NewValue(tftypes.String, "staging-key"),
+		"ngc_org":      tftypes.NewValue(tftypes.String, "staging-org"),
+		"max_retries":  tftypes.NewValue(tftypes.Number, 0),
+	})
+	prod := configureTestProvider(t, map[string]tftypes.Value{
+		"ngc_endpoint": tftypes.NewValue(tftypes.String, prodServer.URL),
+		"ngc_api_key":  tftypes.NewValue(tftypes.String, "prod-key"),
+		"ngc_org":      tftypes.NewValue(tftypes.String, "prod-org"),
+		"max_retries":  tftypes.NewValue(tftypes.Number, 0),
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for org, client := range map[string]*utils.NGCClient{"staging-org": staging, "prod-org": prod} {
+			wg.Add(1)
+			go func(org string, client *utils.NGCClient) {
+				defer wg.Done()
+
+				resp, err := client.NVCFClient().GetNvidiaCloudFunctionVersion(context.Background(), testConfigureFunctionID, testConfigureVersionID)
+				if assert.NoError(t, err) {
+					assert.Equal(t, org+"-function", resp.Function.Name)
+				}
+			}(org, client)
+		}
+	}
+	wg.Wait()
+}
+
+func TestNgcProvider_ConfigureAPIKeySources(t *testing.T) {
+	t.Parallel()
+
+	keyFile := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(keyFile, []byte("file-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		attributes      map[string]tftypes.Value
+		wantApiKey      string
+		wantTokenSource bool
+		wantErrSummary  string
+	}{
+		{
+			name: "APIKey",
+			attributes: map[string]tftypes.Value{
+				"ngc_api_key": tftypes.NewValue(tftypes.String, "hcl-key"),
+			},
+			wantApiKey: "hcl-key",
+		},
+		{
+			name: "APIKeyFile",
+			attributes: map[string]tftypes.Value{
+				"ngc_api_key_file": tftypes.NewValue(tftypes.String, keyFile),
+			},
+			wantApiKey:      "file-key",
+			wantTokenSource: true,
+		},
+		{
+			name: "APIKeyCommand",
+			attributes: map[string]tftypes.Value{
+				"ngc_api_key_command": tftypes.NewValue(tftypes.String, `echo '{"api_key": "command-key"}'`),
+			},
+			wantApiKey:      "command-key",
+			wantTokenSource: true,
+		},
+		{
+			name: "FailingAPIKeyCommand",
+			attributes: map[string]tftypes.Value{
+				"ngc_api_key_command": tftypes.NewValue(tftypes.String, "exit 1"),
+			},
+			wantErrSummary: "Failed to Obtain NGC API Key",
+		},
+		{
+			name: "Conflict",
+			attributes: map[string]tftypes.Value{
+				"ngc_api_key":      tftypes.NewValue(tftypes.String, "hcl-key"),
+				"ngc_api_key_file": tftypes.NewValue(tftypes.String, keyFile),
+			},
+			wantErrSummary: "Conflicting NGC API Key Configuration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.attributes["ngc_org"] = tftypes.NewValue(tftypes.String, "mock-org")
+
+			client, diags := configureTestProviderWithDiagnostics(t, tt.attributes)
+
+			if tt.wantErrSummary != "" {
+				if assert.True(t, diags.HasError()) {
+					assert.Equal(t, tt.wantErrSummary, diags.Errors()[0].Summary())
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("NgcProvider.Configure() diagnostics = %v", diags)
+			}
+			assert.Equal(t, tt.wantApiKey, client.NgcApiKey)
+			assert.Equal(t, tt.wantTokenSource, client.TokenSource != nil)
+		})
+	}
+}
+
+// authBlockValue returns an auth block with the given attributes, the other attributes being null.
+func authBlockValue(attributes map[string]tftypes.Value) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"type":          tftypes.String,
+			"client_id":     tftypes.String,
+			"client_secret": tftypes.String,
+			"token_url":     tftypes.String,
+			"scopes":        tftypes.List{ElementType: tftypes.String},
+		},
+	}
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if v, ok := attributes[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+func TestNgcProvider_ConfigureClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	var scopes sync.Map
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != "mock-client-id" || clientSecret != "mock-client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "invalid_client"}`)
+			return
+		}
+		scopes.Store(r.FormValue("scope"), true)
+		fmt.Fprint(w, `{"access_token": "access-token", "token_type": "bearer", "expires_in": 3600}`)
+	}))
+	defer tokenServer.Close()
+
+	ngcServer := newConfigureTestServer(t, "mock-org", "access-token")
+
+	validAuth := map[string]tftypes.Value{
+		"type":          tftypes.NewValue(tftypes.String, "client_credentials"),
+		"client_id":     tftypes.NewValue(tftypes.String, "mock-client-id"),
+		"client_secret": tftypes.NewValue(tftypes.String, "mock-client-secret"),
+		"token_url":     tftypes.NewValue(tftypes.String, tokenServer.URL),
+		"scopes": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "list_functions"),
+			tftypes.NewValue(tftypes.String, "deploy_function"),
+		}),
+	}
+	withAuth := func(overrides map[string]tftypes.Value) tftypes.Value {
+		attributes := make(map[string]tftypes.Value, len(validAuth))
+		for name, v := range validAuth {
+			attributes[name] = v
+		}
+		for name, v := range overrides {
+			attributes[name] = v
+		}
+		return authBlockValue(attributes)
+	}
+
+	tests := []struct {
+		name           string
+		attributes     map[string]tftypes.Value
+		wantErrSummary string
+	}{
+		{
+			name: "ClientCredentials",
+			attributes: map[string]tftypes.Value{
+				"auth": withAuth(nil),
+			},
+		},
+		{
+			name: "InvalidClientSecret",
+			attributes: map[string]tftypes.Value{
+				"auth": withAuth(map[string]tftypes.Value{
+					"client_secret": tftypes.NewValue(tftypes.String, "wrong-secret"),
+				}),
+			},
+			wantErrSummary: "Failed to Obtain NGC Access Token",
+		},
+		{
+			name: "UnsupportedType",
+			attributes: map[string]tftypes.Value{
+				"auth": withAuth(map[string]tftypes.Value{
+					"type": tftypes.NewValue(tftypes.String, "password"),
+				}),
+			},
+			wantErrSummary: "Invalid auth Configuration",
+		},
+		{
+			name: "MissingTokenURL",
+			attributes: map[string]tftypes.Value{
+				"auth": withAuth(map[string]tftypes.Value{
+					"token_url": tftypes.NewValue(tftypes.String, nil),
+				}),
+			},
+			wantErrSummary: "Missing auth Configuration",
+		},
+		{
+			name: "ConflictWithAPIKey",
+			attributes: map[string]tftypes.Value{
+				"ngc_api_key": tftypes.NewValue(tftypes.String, "hcl-key"),
+				"auth":        withAuth(nil),
+			},
+			wantErrSummary: "Conflicting NGC API Key Configuration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.attributes["ngc_org"] = tftypes.NewValue(tftypes.String, "mock-org")
+			tt.attributes["ngc_endpoint"] = tftypes.NewValue(tftypes.String, ngcServer.URL)
+
+			client, diags := configureTestProviderWithDiagnostics(t, tt.attributes)
+
+			if tt.wantErrSummary != "" {
+				if assert.True(t, diags.HasError()) {
+					assert.Equal(t, tt.wantErrSummary, diags.Errors()[0].Summary())
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("NgcProvider.Configure() diagnostics = %v", diags)
+			}
+			assert.NotNil(t, client.TokenSource)
+
+			_, err := client.NVCFClient().GetNvidiaCloudFunctionVersion(context.Background(), testConfigureFunctionID, testConfigureVersionID)
+			assert.NoError(t, err)
+
+			_, ok := scopes.Load("list_functions deploy_function")
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestNgcProvider_ConfigureRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		attributes      map[string]tftypes.Value
+		wantRateLimiter bool
+		wantErrSummary  string
+	}{
+		{
+			name:            "Default",
+			attributes:      map[string]tftypes.Value{},
+			wantRateLimiter: true,
+		},
+		{
+			name: "Configured",
+			attributes: map[string]tftypes.Value{
+				"requests_per_second": tftypes.NewValue(tftypes.Number, 2.5),
+				"burst":               tftypes.NewValue(tftypes.Number, 5),
+			},
+			wantRateLimiter: true,
+		},
+		{
+			name: "Disabled",
+			attributes: map[string]tftypes.Value{
+				"requests_per_second": tftypes.NewValue(tftypes.Number, 0),
+			},
+		},
+		{
+			name: "InvalidBurst",
+			attributes: map[string]tftypes.Value{
+				"burst": tftypes.NewValue(tftypes.Number, 0),
+			},
+			wantErrSummary: "Invalid burst Configuration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.attributes["ngc_api_key"] = tftypes.NewValue(tftypes.String, "hcl-key")
+			tt.attributes["ngc_org"] = tftypes.NewValue(tftypes.String, "mock-org")
+
+			client, diags := configureTestProviderWithDiagnostics(t, tt.attributes)
+
+			if tt.wantErrSummary != "" {
+				if assert.True(t, diags.HasError()) {
+					assert.Equal(t, tt.wantErrSummary, diags.Errors()[0].Summary())
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("NgcProvider.Configure() diagnostics = %v", diags)
+			}
+			assert.Equal(t, tt.wantRateLimiter, client.RateLimiter != nil)
+			assert.Equal(t, client.RateLimiter, client.NVCFClient().RateLimiter)
+		})
+	}
+}
+
+func TestNgcProvider_ConfigureNGCCLIProfile(t *testing.T) {
+	ngcCLIHome := t.TempDir()
+	t.Setenv("NGC_CLI_HOME", ngcCLIHome)
+	for _, name := range []string{"NGC_CLI_PROFILE", "NGC_API_KEY", "NGC_API_KEY_FILE", "NGC_API_KEY_COMMAND", "NGC_ORG", "NGC_TEAM"} {
+		t.Setenv(name, "")
+	}
+
+	hclAttributes := map[string]tftypes.Value{
+		"ngc_api_key": tftypes.NewValue(tftypes.String, "hcl-key"),
+		"ngc_org":     tftypes.NewValue(tftypes.String, "hcl-org"),
+	}
+
+	tests := []struct {
+		name           string
+		config         string
+		attributes     map[string]tftypes.Value
+		wantApiKey     string
+		wantOrg        string
+		wantErrSummary string
+	}{
+		{
+			name:       "NoCurrentProfile",
+			config:     "[staging]\napikey = staging-key\norg = staging-org\n",
+			attributes: hclAttributes,
+			wantApiKey: "hcl-key",
+			wantOrg:    "hcl-org",
+		},
+		{
+			name:       "MalformedConfig",
+			config:     "[CURRENT\napikey = current-key\n",
+			attributes: hclAttributes,
+			wantApiKey: "hcl-key",
+			wantOrg:    "hcl-org",
+		},
+		{
+			name:       "CurrentProfile",
+			config:     "[CURRENT]\napikey = current-key\norg = current-org\n",
+			attributes: map[string]tftypes.Value{},
+			wantApiKey: "current-key",
+			wantOrg:    "current-org",
+		},
+		{
+			name:   "SelectedProfile",
+			config: "[staging]\napikey = staging-key\norg = staging-org\n",
+			attributes: map[string]tftypes.Value{
+				"profile": tftypes.NewValue(tftypes.String, "staging"),
+			},
+			wantApiKey: "staging-key",
+			wantOrg:    "staging-org",
+		},
+		{
+			name:   "SelectedProfileNotFound",
+			config: "[staging]\napikey = staging-key\norg = staging-org\n",
+			attributes: map[string]tftypes.Value{
+				"ngc_api_key": tftypes.NewValue(tftypes.String, "hcl-key"),
+				"ngc_org":     tftypes.NewValue(tftypes.String, "hcl-org"),
+				"profile":     tftypes.NewValue(tftypes.String, "prod"),
+			},
+			wantErrSummary: "Invalid NGC CLI Config",
+		},
+		{
+			name:   "SelectedProfileMalformedConfig",
+			config: "[staging\napikey = staging-key\n",
+			attributes: map[string]tftypes.Value{
+				"profile": tftypes.NewValue(tftypes.String, "staging"),
+			},
+			wantErrSummary: "Invalid NGC CLI Config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(filepath.Join(ngcCLIHome, "config"), []byte(tt.config), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			client, diags := configureTestProviderWithDiagnostics(t, tt.attributes)
+
+			if tt.wantErrSummary != "" {
+				if assert.True(t, diags.HasError()) {
+					assert.Equal(t, tt.wantErrSummary, diags.Errors()[0].Summary())
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("NgcProvider.Configure() diagnostics = %v", diags)
+			}
+			assert.Equal(t, tt.wantApiKey, client.NgcApiKey)
+			assert.Equal(t, tt.wantOrg, client.NgcOrg)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package testutils
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/fakenvcf"
+)
+
+// FakeNVCF is the fake NVCF API the tests run against when NGC_ENDPOINT is
+// unset, nil when they run against a real NGC endpoint.
+var FakeNVCF *fakenvcf.Server
+
+// fakeNVCFDeploymentDuration outlasts the 1s create timeouts of the timeout tests.
+const fakeNVCFDeploymentDuration = 2 * time.Second
+
+// fakeNVCFTestData is the test data used with the fake NVCF API, unless set in the environment.
+var fakeNVCFTestData = map[string]string{
+	"HELM_URI":                         "https://helm.ngc.nvidia.com/" + fakenvcf.DefaultOrg + "/charts/inference-test-0.1.tgz",
+	"HELM_SERVICE_NAME":                "entrypoint",
+	"HELM_SERVICE_PORT":                "8000",
+	"HELM_INFERENCE_URL":               "/echo",
+	"HELM_HEALTH_URI":                  "/health",
+	"HELM_VALUE_YAML_OVERWRITE":        `{"image":{"repository":"nvcr.io/` + fakenvcf.DefaultOrg + `/fastapi_echo_sample","tag":"0.3"}}`,
+	"HELM_VALUE_YAML_OVERWRITE_UPDATE": `{"image":{"repository":"nvcr.io/` + fakenvcf.DefaultOrg + `/fastapi_echo_sample","tag":"0.3"},"placeholder":"123"}`,
+	"CONTAINER_URI":                    "nvcr.io/" + fakenvcf.DefaultOrg + "/fastapi_echo_sample:0.3",
+	"CONTAINER_PORT":                   "8000",
+	"CONTAINER_INFERENCE_URL":          "/echo",
+	"CONTAINER_HEALTH_URI":             "/health",
+	"BACKEND":                          "fakenvcf-backend",
+	"INSTANCE_TYPE":                    "FAKENVCF.GPU.L40_1x",
+	"GPU_TYPE":                         "L40",
+	"MODEL_1_NAME":                     "gemma_2b_base",
+	"MODEL_1_VERSION":                  "1.1",
+	"MODEL_1_URI":                      "/v2/org/nvidia/team/nemo/models/gemma_2b_base/1.1/files",
+	"AUTHORIZED_PARTY_1":               "fakenvcf-authorized-party-1",
+	"AUTHORIZED_PARTY_2":               "fakenvcf-authorized-party-2",
+}
+
+// startFakeNVCF starts the fake NVCF API and points the test client and the
+// provider, through the environment variables, to it.
+func startFakeNVCF() {
+	FakeNVCF = fakenvcf.NewServer(fakenvcf.Config{
+		DeploymentDuration: fakeNVCFDeploymentDuration,
+	})
+	config := FakeNVCF.Config()
+
+	environment := map[string]string{
+		"NGC_ENDPOINT": FakeNVCF.URL(),
+		"NGC_API_KEY":  config.APIKey,
+		"NGC_ORG":      config.Org,
+		"NCA_ID":       config.NcaID,
+	}
+	for k, v := range fakeNVCFTestData {
+		if os.Getenv(k) == "" {
+			environment[k] = v
+		}
+	}
+
+	for k, v := range environment {
+		if err := os.Setenv(k, v); err != nil {
+			log.Fatalf("Error setting %s for the fake NVCF API: %s", k, err)
+		}
+	}
+
+	log.Printf("NGC_ENDPOINT is not set, running against the fake NVCF API at %s", FakeNVCF.URL())
+}
@@ -67,10 +67,16 @@ var TestAuthorizedParty1 string
 var TestAuthorizedParty2 string
 
 func init() {
-	err := godotenv.Load(os.Getenv("TEST_ENV_FILE"))
+	if testEnvFile := os.Getenv("TEST_ENV_FILE"); testEnvFile != "" {
+		err := godotenv.Load(testEnvFile)
 
-	if err != nil {
-		log.Fatal("Error loading test config file")
+		if err != nil {
+			log.Fatal("Error loading test config file")
+		}
+	}
+
+	if os.Getenv("NGC_ENDPOINT") == "" {
+		startFakeNVCF()
 	}
 
 	TestNGCClient = &utils.NGCClient{
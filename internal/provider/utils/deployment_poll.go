@@ -0,0 +1,232 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DefaultDeploymentPollInterval          = 5 * time.Second
+	DefaultDeploymentPollMaxInterval       = 60 * time.Second
+	DefaultDeploymentPollMultiplier        = 2.0
+	DefaultDeploymentPollMaxTransientError = 5
+)
+
+// DeploymentPollPolicy controls how WaitingDeploymentCompleted polls the
+// deployment status. Zero fields fall back to the defaults.
+type DeploymentPollPolicy struct {
+	// Interval is the wait before the second poll, multiplied by Multiplier after every poll.
+	Interval time.Duration
+	// MaxInterval caps the wait between two polls.
+	MaxInterval time.Duration
+	// Multiplier is the growth factor of the interval, must be at least 1.
+	Multiplier float64
+	// MaxTransientErrors is the number of consecutive transient read errors tolerated.
+	MaxTransientErrors int
+}
+
+func DefaultDeploymentPollPolicy() DeploymentPollPolicy {
+	return DeploymentPollPolicy{
+		Interval:           DefaultDeploymentPollInterval,
+		MaxInterval:        DefaultDeploymentPollMaxInterval,
+		Multiplier:         DefaultDeploymentPollMultiplier,
+		MaxTransientErrors: DefaultDeploymentPollMaxTransientError,
+	}
+}
+
+func (p DeploymentPollPolicy) withDefaults() DeploymentPollPolicy {
+	if p.Interval <= 0 {
+		p.Interval = DefaultDeploymentPollInterval
+	}
+	if p.MaxInterval <= 0 {
+		p.MaxInterval = max(DefaultDeploymentPollMaxInterval, p.Interval)
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultDeploymentPollMultiplier
+	}
+	if p.MaxTransientErrors <= 0 {
+		p.MaxTransientErrors = DefaultDeploymentPollMaxTransientError
+	}
+	return p
+}
+
+// nextInterval grows the interval exponentially up to MaxInterval.
+func (p DeploymentPollPolicy) nextInterval(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * p.Multiplier)
+	if next > p.MaxInterval {
+		return p.MaxInterval
+	}
+	return next
+}
+
+// isTransientPollError reports whether a deployment read error is worth another poll:
+// a connection level failure or a status code the retry policy considers transient.
+func (c *NVCFClient) isTransientPollError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+
+	retryableStatusCodes := c.RetryPolicy.RetryableStatusCodes
+	if retryableStatusCodes == nil {
+		retryableStatusCodes = DefaultRetryableStatusCodes()
+	}
+	return retryableStatusCodes[apiErr.HTTPStatusCode]
+}
+
+// DeploymentError is returned when a deployment ends in a failed status.
+type DeploymentError struct {
+	FunctionID        string
+	FunctionVersionID string
+	FunctionStatus    string
+	HealthInfo        interface{}
+}
+
+func (e *DeploymentError) Error() string {
+	if e.HealthInfo == nil {
+		return fmt.Sprintf("unexpected status %s", e.FunctionStatus)
+	}
+
+	healthInfo, err := json.Marshal(e.HealthInfo)
+	if err != nil {
+		return fmt.Sprintf("unexpected status %s. Health info: %v", e.FunctionStatus, e.HealthInfo)
+	}
+	return fmt.Sprintf("unexpected status %s. Health info: %s", e.FunctionStatus, healthInfo)
+}
+
+// DeploymentTimeoutError is returned when the deployment is not ACTIVE before
+// the context is done. It keeps the last observed instances to tell a lack of
+// capacity apart from a container failing to start.
+type DeploymentTimeoutError struct {
+	FunctionID        string
+	FunctionVersionID string
+	FunctionStatus    string
+	Elapsed           time.Duration
+	MinInstances      int
+	ActiveInstances   []NvidiaCloudFunctionActiveInstance
+}
+
+func (e *DeploymentTimeoutError) Error() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "timeout occurred after %s waiting for the deployment of function version %s, last status %s, %d/%d instances running",
+		e.Elapsed.Round(time.Second), e.FunctionVersionID, e.FunctionStatus, runningInstancesCount(e.ActiveInstances), e.MinInstances)
+
+	if len(e.ActiveInstances) == 0 {
+		sb.WriteString(". No instance was started, the backend may lack capacity for the requested GPU and instance type")
+		return sb.String()
+	}
+
+	stuckInstances := make([]string, 0)
+	for _, instance := range e.ActiveInstances {
+		if !isRunningInstanceStatus(instance.InstanceStatus) {
+			stuckInstances = append(stuckInstances, fmt.Sprintf("%s (%s, %s %s on %s)", instance.InstanceID, instance.InstanceStatus, instance.Gpu, instance.InstanceType, instance.Backend))
+		}
+	}
+	if len(stuckInstances) > 0 {
+		fmt.Fprintf(&sb, ". Instances not running, the container may fail to start or to become healthy: %s", strings.Join(stuckInstances, ", "))
+	}
+	return sb.String()
+}
+
+func isRunningInstanceStatus(instanceStatus string) bool {
+	return instanceStatus == "ACTIVE" || instanceStatus == "RUNNING"
+}
+
+func runningInstancesCount(instances []NvidiaCloudFunctionActiveInstance) int {
+	count := 0
+	for _, instance := range instances {
+		if isRunningInstanceStatus(instance.InstanceStatus) {
+			count++
+		}
+	}
+	return count
+}
+
+// deploymentProgress records what the waiter observed, to report progress on every poll.
+type deploymentProgress struct {
+	functionID        string
+	functionVersionID string
+	startedAt         time.Time
+	functionStatus    string
+	minInstances      int
+	activeInstances   []NvidiaCloudFunctionActiveInstance
+}
+
+func newDeploymentProgress(functionID string, functionVersionID string) *deploymentProgress {
+	return &deploymentProgress{
+		functionID:        functionID,
+		functionVersionID: functionVersionID,
+		startedAt:         time.Now(),
+	}
+}
+
+func (p *deploymentProgress) elapsed() time.Duration {
+	return time.Since(p.startedAt).Round(time.Second)
+}
+
+func (p *deploymentProgress) observeDeployment(ctx context.Context, deployment NvidiaCloudFunctionDeployment) {
+	if deployment.FunctionStatus != p.functionStatus {
+		tflog.Info(ctx, "Function deployment status changed", map[string]interface{}{
+			"function_id":     p.functionID,
+			"version_id":      p.functionVersionID,
+			"previous_status": p.functionStatus,
+			"status":          deployment.FunctionStatus,
+			"elapsed":         p.elapsed().String(),
+		})
+		p.functionStatus = deployment.FunctionStatus
+	}
+
+	minInstances := 0
+	for _, spec := range deployment.DeploymentSpecifications {
+		minInstances += spec.MinInstances
+	}
+	p.minInstances = minInstances
+}
+
+func (p *deploymentProgress) observeInstances(ctx context.Context, activeInstances []NvidiaCloudFunctionActiveInstance) {
+	p.activeInstances = activeInstances
+
+	instanceStatuses := make([]string, 0, len(activeInstances))
+	for _, instance := range activeInstances {
+		instanceStatuses = append(instanceStatuses, fmt.Sprintf("%s=%s", instance.InstanceID, instance.InstanceStatus))
+	}
+
+	tflog.Info(ctx, "Waiting for function deployment", map[string]interface{}{
+		"function_id":       p.functionID,
+		"version_id":        p.functionVersionID,
+		"status":            p.functionStatus,
+		"elapsed":           p.elapsed().String(),
+		"active_instances":  len(activeInstances),
+		"running_instances": runningInstancesCount(activeInstances),
+		"min_instances":     p.minInstances,
+		"instance_statuses": instanceStatuses,
+	})
+}
+
+func (p *deploymentProgress) timeoutError() error {
+	return &DeploymentTimeoutError{
+		FunctionID:        p.functionID,
+		FunctionVersionID: p.functionVersionID,
+		FunctionStatus:    p.functionStatus,
+		Elapsed:           time.Since(p.startedAt),
+		MinInstances:      p.minInstances,
+		ActiveInstances:   p.activeInstances,
+	}
+}
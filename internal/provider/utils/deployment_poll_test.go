@@ -0,0 +1,465 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDeploymentPollPolicy() DeploymentPollPolicy {
+	return DeploymentPollPolicy{
+		Interval:           time.Millisecond,
+		MaxInterval:        4 * time.Millisecond,
+		Multiplier:         2,
+		MaxTransientErrors: 2,
+	}
+}
+
+type mockDeploymentPoll struct {
+	statusCode     int
+	functionStatus string
+	healthInfo     string
+}
+
+// newDeploymentPollServer replies to deployment reads with the given polls in order, repeating the
+// last one, and to function version reads with the given active instances.
+func newDeploymentPollServer(t *testing.T, polls []mockDeploymentPoll, activeInstances string, attempts *int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/nvcf/deployments/") {
+			if activeInstances == "" {
+				activeInstances = "[]"
+			}
+			fmt.Fprintf(w, `{"function": {"id": "%s", "versionId": "%s", "activeInstances": %s}}`, mockFunctionID, mockVersionID, activeInstances)
+			return
+		}
+
+		attempt := int(atomic.AddInt32(attempts, 1)) - 1
+		if attempt >= len(polls) {
+			attempt = len(polls) - 1
+		}
+		poll := polls[attempt]
+
+		w.WriteHeader(poll.statusCode)
+		if poll.statusCode != http.StatusOK {
+			fmt.Fprint(w, mockErrorResponse)
+			return
+		}
+
+		healthInfo := "null"
+		if poll.healthInfo != "" {
+			healthInfo = poll.healthInfo
+		}
+		fmt.Fprintf(w, `{"deployment": {"functionId": "%s", "functionVersionId": "%s", "functionStatus": "%s", "healthInfo": %s, "deploymentSpecifications": [%s]}}`,
+			mockFunctionID, mockVersionID, poll.functionStatus, healthInfo, mockDeploymentSpecification)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNVCFClient_WaitingDeploymentCompletedPolling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		polls           []mockDeploymentPoll
+		activeInstances string
+		timeout         time.Duration
+		wantAttempts    int32
+		wantErrMsg      string
+	}{
+		{
+			name: "ActiveAfterDeploying",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusOK, functionStatus: "DEPLOYING"},
+				{statusCode: http.StatusOK, functionStatus: "DEPLOYING"},
+				{statusCode: http.StatusOK, functionStatus: "ACTIVE"},
+			},
+			wantAttempts: 3,
+		},
+		{
+			name: "ActiveAfterInactive",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusOK, functionStatus: "INACTIVE"},
+				{statusCode: http.StatusOK, functionStatus: "DEPLOYING"},
+				{statusCode: http.StatusOK, functionStatus: "ACTIVE"},
+			},
+			wantAttempts: 3,
+		},
+		{
+			name: "TransientErrorsTolerated",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusOK, functionStatus: "DEPLOYING"},
+				{statusCode: http.StatusServiceUnavailable},
+				{statusCode: http.StatusOK, functionStatus: "DEPLOYING"},
+				{statusCode: http.StatusBadGateway},
+				{statusCode: http.StatusGatewayTimeout},
+				{statusCode: http.StatusOK, functionStatus: "ACTIVE"},
+			},
+			wantAttempts: 6,
+		},
+		{
+			name: "TooManyTransientErrors",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusServiceUnavailable},
+			},
+			wantAttempts: 3,
+			wantErrMsg:   mockErrorDetail,
+		},
+		{
+			name: "NonTransientError",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusOK, functionStatus: "DEPLOYING"},
+				{statusCode: http.StatusInternalServerError},
+			},
+			wantAttempts: 2,
+			wantErrMsg:   mockErrorDetail,
+		},
+		{
+			name: "ErrorWithHealthInfo",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusOK, functionStatus: "DEPLOYING"},
+				{statusCode: http.StatusOK, functionStatus: "ERROR", healthInfo: `[{"error": "ImagePullBackOff"}]`},
+			},
+			wantAttempts: 2,
+			wantErrMsg:   `unexpected status ERROR. Health info: [{"error":"ImagePullBackOff"}]`,
+		},
+		{
+			name: "DegradedWithoutHealthInfo",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusOK, functionStatus: "DEGRADED"},
+			},
+			wantAttempts: 1,
+			wantErrMsg:   "unexpected status DEGRADED",
+		},
+		{
+			name: "TimeoutWithoutInstance",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusOK, functionStatus: "DEPLOYING"},
+			},
+			timeout:    20 * time.Millisecond,
+			wantErrMsg: "No instance was started, the backend may lack capacity",
+		},
+		{
+			name: "TimeoutWithStuckInstances",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusOK, functionStatus: "DEPLOYING"},
+			},
+			activeInstances: `[
+				{"instanceId": "i-running", "instanceStatus": "ACTIVE", "gpu": "L40", "instanceType": "gl40_1.br20_2xlarge", "backend": "GFN"},
+				{"instanceId": "i-stuck", "instanceStatus": "STARTING", "gpu": "L40", "instanceType": "gl40_1.br20_2xlarge", "backend": "GFN"}
+			]`,
+			timeout:    20 * time.Millisecond,
+			wantErrMsg: "1/1 instances running. Instances not running, the container may fail to start or to become healthy: i-stuck (STARTING, L40 gl40_1.br20_2xlarge on GFN)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts int32
+			server := newDeploymentPollServer(t, tt.polls, tt.activeInstances, &attempts)
+
+			c := &NVCFClient{
+				NgcEndpoint:          server.URL,
+				NgcApiKey:            mockApiKey,
+				NgcOrg:               mockOrg,
+				HttpClient:           server.Client(),
+				DeploymentPollPolicy: testDeploymentPollPolicy(),
+			}
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			err := c.WaitingDeploymentCompleted(ctx, mockFunctionID, mockVersionID)
+
+			if tt.wantErrMsg == "" {
+				assert.NoError(t, err)
+			} else if tt.timeout > 0 {
+				var timeoutErr *DeploymentTimeoutError
+				if !errors.As(err, &timeoutErr) {
+					t.Fatalf("NVCFClient.WaitingDeploymentCompleted() error = %v, want *DeploymentTimeoutError", err)
+				}
+				assert.True(t, strings.HasPrefix(err.Error(), "timeout occurred after "))
+				assert.Contains(t, err.Error(), tt.wantErrMsg)
+			} else {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			}
+			if tt.wantAttempts > 0 {
+				assert.Equal(t, tt.wantAttempts, atomic.LoadInt32(&attempts))
+			}
+		})
+	}
+}
+
+func TestNVCFClient_WaitingDeploymentCompletedDeploymentError(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	server := newDeploymentPollServer(t, []mockDeploymentPoll{
+		{statusCode: http.StatusOK, functionStatus: "ERROR", healthInfo: `{"reason": "OOMKilled"}`},
+	}, "", &attempts)
+
+	c := &NVCFClient{
+		NgcEndpoint: server.URL,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient:  server.Client(),
+	}
+
+	err := c.WaitingDeploymentCompleted(context.Background(), mockFunctionID, mockVersionID)
+
+	var deploymentErr *DeploymentError
+	if !errors.As(err, &deploymentErr) {
+		t.Fatalf("NVCFClient.WaitingDeploymentCompleted() error = %v, want *DeploymentError", err)
+	}
+	assert.Equal(t, "ERROR", deploymentErr.FunctionStatus)
+	assert.Equal(t, mockVersionID, deploymentErr.FunctionVersionID)
+	assert.Equal(t, map[string]interface{}{"reason": "OOMKilled"}, deploymentErr.HealthInfo)
+}
+
+// newDeploymentScaleServer replies to deployment reads with the given statuses in order, and to function
+// version reads with the given active instances in order, repeating the last ones.
+func newDeploymentScaleServer(t *testing.T, statuses []string, activeInstances []string, attempts *int32) *httptest.Server {
+	t.Helper()
+
+	var instanceReads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/nvcf/deployments/") {
+			read := min(int(atomic.AddInt32(&instanceReads, 1))-1, len(activeInstances)-1)
+			fmt.Fprintf(w, `{"function": {"id": "%s", "versionId": "%s", "activeInstances": %s}}`, mockFunctionID, mockVersionID, activeInstances[read])
+			return
+		}
+
+		attempt := min(int(atomic.AddInt32(attempts, 1))-1, len(statuses)-1)
+		fmt.Fprintf(w, `{"deployment": {"functionId": "%s", "functionVersionId": "%s", "functionStatus": "%s", "deploymentSpecifications": [%s]}}`,
+			mockFunctionID, mockVersionID, statuses[attempt], mockDeploymentSpecification)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNVCFClient_WaitingDeploymentScaled(t *testing.T) {
+	t.Parallel()
+
+	const (
+		noInstance      = `[]`
+		pendingInstance = `[{"instanceId": "i-new", "instanceStatus": "PENDING"}]`
+		runningInstance = `[{"instanceId": "i-new", "instanceStatus": "ACTIVE"}]`
+		twoInstances    = `[{"instanceId": "i-old", "instanceStatus": "ACTIVE"}, {"instanceId": "i-new", "instanceStatus": "ACTIVE"}]`
+	)
+
+	tests := []struct {
+		name            string
+		statuses        []string
+		activeInstances []string
+		timeout         time.Duration
+		wantAttempts    int32
+		wantErrMsg      string
+	}{
+		{
+			name:            "ScaledDownWhileActive",
+			statuses:        []string{"ACTIVE"},
+			activeInstances: []string{twoInstances, twoInstances, runningInstance},
+			wantAttempts:    3,
+		},
+		{
+			name:            "ScaledUpWhileActive",
+			statuses:        []string{"ACTIVE"},
+			activeInstances: []string{noInstance, pendingInstance, runningInstance},
+			wantAttempts:    3,
+		},
+		{
+			name:            "InactiveWhileRedeployed",
+			statuses:        []string{"ACTIVE", "INACTIVE", "DEPLOYING", "ACTIVE"},
+			activeInstances: []string{noInstance, noInstance, pendingInstance, runningInstance},
+			wantAttempts:    4,
+		},
+		{
+			name:            "Error",
+			statuses:        []string{"ERROR"},
+			activeInstances: []string{runningInstance},
+			wantAttempts:    1,
+			wantErrMsg:      "unexpected status ERROR",
+		},
+		{
+			name:            "TimeoutWithoutScaleDown",
+			statuses:        []string{"ACTIVE"},
+			activeInstances: []string{twoInstances},
+			timeout:         20 * time.Millisecond,
+			wantErrMsg:      "last status ACTIVE, 2/1 instances running",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts int32
+			server := newDeploymentScaleServer(t, tt.statuses, tt.activeInstances, &attempts)
+
+			c := &NVCFClient{
+				NgcEndpoint:          server.URL,
+				NgcApiKey:            mockApiKey,
+				NgcOrg:               mockOrg,
+				HttpClient:           server.Client(),
+				DeploymentPollPolicy: testDeploymentPollPolicy(),
+			}
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			err := c.WaitingDeploymentScaled(ctx, mockFunctionID, mockVersionID, []NvidiaCloudFunctionDeploymentSpecification{
+				{MinInstances: 1, MaxInstances: 1},
+			})
+
+			if tt.wantErrMsg == "" {
+				assert.NoError(t, err)
+			} else if tt.timeout > 0 {
+				var timeoutErr *DeploymentTimeoutError
+				if !errors.As(err, &timeoutErr) {
+					t.Fatalf("NVCFClient.WaitingDeploymentScaled() error = %v, want *DeploymentTimeoutError", err)
+				}
+				assert.Contains(t, err.Error(), tt.wantErrMsg)
+			} else {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			}
+			if tt.wantAttempts > 0 {
+				assert.Equal(t, tt.wantAttempts, atomic.LoadInt32(&attempts))
+			}
+		})
+	}
+}
+
+func TestNVCFClient_WaitingDeploymentDeleted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		polls        []mockDeploymentPoll
+		timeout      time.Duration
+		wantAttempts int32
+		wantErrMsg   string
+	}{
+		{
+			name: "DeletedAfterTeardown",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusOK, functionStatus: "ACTIVE"},
+				{statusCode: http.StatusOK, functionStatus: "INACTIVE"},
+				{statusCode: http.StatusNotFound},
+			},
+			wantAttempts: 3,
+		},
+		{
+			name: "TransientErrorsTolerated",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusServiceUnavailable},
+				{statusCode: http.StatusNotFound},
+			},
+			wantAttempts: 2,
+		},
+		{
+			name: "NonTransientError",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusInternalServerError},
+			},
+			wantAttempts: 1,
+			wantErrMsg:   mockErrorDetail,
+		},
+		{
+			name: "Timeout",
+			polls: []mockDeploymentPoll{
+				{statusCode: http.StatusOK, functionStatus: "INACTIVE"},
+			},
+			timeout:    20 * time.Millisecond,
+			wantErrMsg: "waiting for the deletion of the deployment of function version " + mockVersionID + ", last status INACTIVE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts int32
+			server := newDeploymentPollServer(t, tt.polls, "", &attempts)
+
+			c := &NVCFClient{
+				NgcEndpoint:          server.URL,
+				NgcApiKey:            mockApiKey,
+				NgcOrg:               mockOrg,
+				HttpClient:           server.Client(),
+				DeploymentPollPolicy: testDeploymentPollPolicy(),
+			}
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			err := c.WaitingDeploymentDeleted(ctx, mockFunctionID, mockVersionID)
+
+			if tt.wantErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+			}
+			if tt.wantAttempts > 0 {
+				assert.Equal(t, tt.wantAttempts, atomic.LoadInt32(&attempts))
+			}
+		})
+	}
+}
+
+func TestDeploymentPollPolicy_nextInterval(t *testing.T) {
+	t.Parallel()
+
+	p := DeploymentPollPolicy{}.withDefaults()
+
+	interval := p.Interval
+	intervals := make([]time.Duration, 0)
+	for i := 0; i < 6; i++ {
+		intervals = append(intervals, interval)
+		interval = p.nextInterval(interval)
+	}
+
+	assert.Equal(t, []time.Duration{
+		5 * time.Second,
+		10 * time.Second,
+		20 * time.Second,
+		40 * time.Second,
+		60 * time.Second,
+		60 * time.Second,
+	}, intervals)
+}
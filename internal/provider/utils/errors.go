@@ -0,0 +1,166 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NVCF reports a missing deployment with this description instead of a not found status.
+const functionDeploymentNotFoundDescription = "failed to find function deployment"
+
+// APIError is returned by NVCFClient when the API replies with an unexpected status code.
+type APIError struct {
+	Method         string
+	URL            string
+	HTTPStatusCode int
+
+	// Populated from the requestStatus error format.
+	StatusCode        string
+	StatusDescription string
+	RequestID         string
+
+	// Populated from the problem details error format.
+	Title  string
+	Detail string
+
+	// Populated from the gateway error format.
+	Message string
+
+	// Body is the raw response body, kept when it carries no known error message.
+	Body string
+}
+
+func newAPIError(method string, requestURL string, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:         method,
+		URL:            requestURL,
+		HTTPStatusCode: statusCode,
+	}
+
+	// The unauthenticated response format is different with others
+	if statusCode == http.StatusUnauthorized {
+		return apiErr
+	}
+
+	var errResponseObject ErrorResponse
+	if err := json.Unmarshal(body, &errResponseObject); err != nil {
+		apiErr.Body = strings.TrimSpace(string(body))
+		return apiErr
+	}
+
+	apiErr.StatusCode = errResponseObject.RequestStatus.StatusCode
+	apiErr.StatusDescription = errResponseObject.RequestStatus.StatusDescription
+	apiErr.RequestID = errResponseObject.RequestStatus.RequestID
+	apiErr.Title = errResponseObject.Title
+	apiErr.Detail = errResponseObject.Detail
+	apiErr.Message = errResponseObject.Message
+
+	if apiErr.message() == "" {
+		apiErr.Body = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
+
+// message returns the error message returned by the API, if any.
+func (e *APIError) message() string {
+	if e.StatusDescription != "" {
+		return e.StatusDescription
+	}
+
+	if e.Detail != "" {
+		return e.Detail
+	}
+
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return e.Title
+}
+
+func (e *APIError) Error() string {
+	if e.HTTPStatusCode == http.StatusUnauthorized {
+		return "not authenticated"
+	}
+
+	if message := e.message(); message != "" {
+		return message
+	}
+
+	// An empty or unknown body, such as a 502 from a proxy, still tells the status.
+	message := fmt.Sprintf("HTTP %d: %s", e.HTTPStatusCode, http.StatusText(e.HTTPStatusCode))
+	if e.Body != "" {
+		message = fmt.Sprintf("%s. Response body: %s", message, e.Body)
+	}
+	return message
+}
+
+// IsNotFound reports whether err is an APIError for a missing function, version or deployment.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.HTTPStatusCode == http.StatusNotFound ||
+		apiErr.StatusCode == "NOT_FOUND" ||
+		strings.EqualFold(apiErr.StatusDescription, functionDeploymentNotFoundDescription)
+}
+
+// IsConflict reports whether err is an APIError caused by a conflicting resource state.
+func IsConflict(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.HTTPStatusCode == http.StatusConflict || apiErr.StatusCode == "CONFLICT"
+}
+
+// IsUnauthorized reports whether err is an APIError caused by a missing, invalid or insufficient credential.
+func IsUnauthorized(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.HTTPStatusCode == http.StatusUnauthorized ||
+		apiErr.HTTPStatusCode == http.StatusForbidden ||
+		apiErr.StatusCode == "UNAUTHORIZED" ||
+		apiErr.StatusCode == "FORBIDDEN"
+}
+
+// ErrorDetail formats err for a diagnostic, including the NVCF request ID when one was returned.
+func ErrorDetail(err error) string {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	if apiErr.HTTPStatusCode != http.StatusUnauthorized && apiErr.message() == "" {
+		return apiErr.Error()
+	}
+
+	detail := fmt.Sprintf("%s (HTTP %d)", apiErr.Error(), apiErr.HTTPStatusCode)
+	if apiErr.Title != "" && apiErr.Title != apiErr.Error() {
+		detail = fmt.Sprintf("%s: %s", apiErr.Title, detail)
+	}
+	if apiErr.RequestID != "" {
+		detail = fmt.Sprintf("%s\n\nNVCF Request ID: %s", detail, apiErr.RequestID)
+	}
+
+	return detail
+}
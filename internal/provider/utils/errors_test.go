@@ -0,0 +1,202 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mockProblemDetailsNotFoundResponse = `
+	{
+		"type": "urn:kaizen:problem-details:not-found",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "Function '033c9664-f5b0-4bd2-8918-5aab085fc8db' Not found",
+		"instance": "/v2/nvcf/functions/033c9664-f5b0-4bd2-8918-5aab085fc8db/versions"
+	}
+	`
+
+func TestNVCFClient_sendRequestAPIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		statusCode         int
+		body               string
+		wantErrMsg         string
+		wantStatusCode     string
+		wantRequestID      string
+		wantTitle          string
+		wantIsNotFound     bool
+		wantIsConflict     bool
+		wantIsUnauthorized bool
+	}{
+		{
+			name:           "RequestStatusFormat",
+			statusCode:     http.StatusBadRequest,
+			body:           mockErrorResponse,
+			wantErrMsg:     mockErrorDetail,
+			wantStatusCode: "INVALID_REQUEST",
+			wantRequestID:  "a3023cc6-2705972",
+		},
+		{
+			name:           "ProblemDetailsFormat",
+			statusCode:     http.StatusNotFound,
+			body:           mockProblemDetailsNotFoundResponse,
+			wantErrMsg:     "Function '033c9664-f5b0-4bd2-8918-5aab085fc8db' Not found",
+			wantTitle:      "Not Found",
+			wantIsNotFound: true,
+		},
+		{
+			name:           "DeploymentNotFound",
+			statusCode:     http.StatusBadRequest,
+			body:           `{"requestStatus": {"statusCode": "INVALID_REQUEST", "statusDescription": "failed to find function deployment", "requestId": "mock"}}`,
+			wantErrMsg:     "failed to find function deployment",
+			wantStatusCode: "INVALID_REQUEST",
+			wantRequestID:  "mock",
+			wantIsNotFound: true,
+		},
+		{
+			name:           "Conflict",
+			statusCode:     http.StatusConflict,
+			body:           `{"requestStatus": {"statusCode": "CONFLICT", "statusDescription": "function version is being deployed", "requestId": "mock"}}`,
+			wantErrMsg:     "function version is being deployed",
+			wantStatusCode: "CONFLICT",
+			wantRequestID:  "mock",
+			wantIsConflict: true,
+		},
+		{
+			name:               "Unauthenticated",
+			statusCode:         http.StatusUnauthorized,
+			body:               "",
+			wantErrMsg:         "not authenticated",
+			wantIsUnauthorized: true,
+		},
+		{
+			name:       "UnparsableBody",
+			statusCode: http.StatusInternalServerError,
+			body:       "<html>oops</html>",
+			wantErrMsg: "HTTP 500: Internal Server Error. Response body: <html>oops</html>",
+		},
+		{
+			name:       "EmptyBody",
+			statusCode: http.StatusBadGateway,
+			body:       "",
+			wantErrMsg: "HTTP 502: Bad Gateway",
+		},
+		{
+			name:       "NonJSONBody",
+			statusCode: http.StatusServiceUnavailable,
+			body:       "upstream connect error\n",
+			wantErrMsg: "HTTP 503: Service Unavailable. Response body: upstream connect error",
+		},
+		{
+			name:       "UnknownJSONBody",
+			statusCode: http.StatusBadGateway,
+			body:       `{"error": "upstream unavailable"}`,
+			wantErrMsg: `HTTP 502: Bad Gateway. Response body: {"error": "upstream unavailable"}`,
+		},
+		{
+			name:       "GatewayMessageFormat",
+			statusCode: http.StatusTooManyRequests,
+			body:       `{"message": "API rate limit exceeded"}`,
+			wantErrMsg: "API rate limit exceeded",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			c := &NVCFClient{
+				NgcEndpoint: server.URL,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				HttpClient:  server.Client(),
+			}
+
+			_, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("NVCFClient.GetNvidiaCloudFunctionVersion() error = %v, want *APIError", err)
+			}
+			assert.Equal(t, tt.wantErrMsg, err.Error())
+			assert.Equal(t, tt.statusCode, apiErr.HTTPStatusCode)
+			assert.Equal(t, http.MethodGet, apiErr.Method)
+			assert.Equal(t, tt.wantStatusCode, apiErr.StatusCode)
+			assert.Equal(t, tt.wantRequestID, apiErr.RequestID)
+			assert.Equal(t, tt.wantTitle, apiErr.Title)
+			assert.Equal(t, tt.wantIsNotFound, IsNotFound(err))
+			assert.Equal(t, tt.wantIsConflict, IsConflict(err))
+			assert.Equal(t, tt.wantIsUnauthorized, IsUnauthorized(err))
+		})
+	}
+}
+
+func TestErrorDetail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "PlainError",
+			err:  errors.New("mock"),
+			want: "mock",
+		},
+		{
+			name: "APIErrorWithRequestID",
+			err: &APIError{
+				HTTPStatusCode:    http.StatusBadRequest,
+				StatusDescription: mockErrorDetail,
+				RequestID:         "a3023cc6-2705972",
+			},
+			want: mockErrorDetail + " (HTTP 400)\n\nNVCF Request ID: a3023cc6-2705972",
+		},
+		{
+			name: "WrappedAPIErrorWithTitle",
+			err: fmt.Errorf("wrapped: %w", &APIError{
+				HTTPStatusCode: http.StatusNotFound,
+				Title:          "Not Found",
+				Detail:         "Function Not found",
+			}),
+			want: "Not Found: Function Not found (HTTP 404)",
+		},
+		{
+			name: "APIErrorWithEmptyBody",
+			err: &APIError{
+				HTTPStatusCode: http.StatusBadGateway,
+			},
+			want: "HTTP 502: Bad Gateway",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorDetail(tt.err))
+		})
+	}
+}
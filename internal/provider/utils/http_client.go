@@ -0,0 +1,120 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// HTTPClientConfig describes how the NGC API is reached. The zero value
+// behaves like cleanhttp.DefaultPooledClient.
+type HTTPClientConfig struct {
+	// ProxyURL overrides the proxy taken from the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables.
+	ProxyURL string
+	// CACertFile and CACertPEM are appended to the system certificate pool.
+	CACertFile string
+	CACertPEM  string
+	// ClientCert and ClientKey enable mTLS, each is either a PEM content or a file path.
+	ClientCert string
+	ClientKey  string
+	// InsecureSkipVerify disables the verification of the server certificate.
+	InsecureSkipVerify bool
+}
+
+func NewHTTPClient(config HTTPClientConfig) (*http.Client, error) {
+	transport := cleanhttp.DefaultPooledTransport()
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q, expected an URL such as \"http://proxy.example.com:3128\"", config.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: transport}, nil
+}
+
+func (config HTTPClientConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify, //nolint:gosec
+	}
+
+	if config.CACertFile != "" || config.CACertPEM != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if config.CACertFile != "" {
+			caCert, err := os.ReadFile(config.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+			}
+			if !rootCAs.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("no PEM certificate found in CA certificate file %s", config.CACertFile)
+			}
+		}
+
+		if config.CACertPEM != "" && !rootCAs.AppendCertsFromPEM([]byte(config.CACertPEM)) {
+			return nil, errors.New("no PEM certificate found in CA certificate PEM")
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if config.ClientCert != "" || config.ClientKey != "" {
+		if config.ClientCert == "" || config.ClientKey == "" {
+			return nil, errors.New("client certificate and client key must be set together")
+		}
+
+		clientCert, err := readPEMOrFile(config.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		clientKey, err := readPEMOrFile(config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %w", err)
+		}
+
+		certificate, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// readPEMOrFile returns the value itself when it is a PEM content, the content of the file it points to otherwise.
+func readPEMOrFile(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
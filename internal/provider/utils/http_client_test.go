@@ -0,0 +1,220 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generateClientCertificate returns a self-signed client certificate and its key, PEM encoded.
+func generateClientCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-ngc-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeTempFile(t *testing.T, name string, content []byte) string {
+	t.Helper()
+
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestNewHTTPClient_TLS(t *testing.T) {
+	t.Parallel()
+
+	clientCertPEM, clientKeyPEM := generateClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(clientCertPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	mtlsServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	mtlsServer.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	mtlsServer.StartTLS()
+	defer mtlsServer.Close()
+
+	caCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	mtlsCACertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: mtlsServer.Certificate().Raw})
+
+	tests := []struct {
+		name          string
+		url           string
+		config        HTTPClientConfig
+		wantConfigErr bool
+		wantErr       bool
+	}{
+		{
+			name:    "UnknownCA",
+			url:     server.URL,
+			wantErr: true,
+		},
+		{
+			name:   "CACertPEM",
+			url:    server.URL,
+			config: HTTPClientConfig{CACertPEM: string(caCertPEM)},
+		},
+		{
+			name:   "CACertFile",
+			url:    server.URL,
+			config: HTTPClientConfig{CACertFile: writeTempFile(t, "ca.pem", caCertPEM)},
+		},
+		{
+			name:   "InsecureSkipVerify",
+			url:    server.URL,
+			config: HTTPClientConfig{InsecureSkipVerify: true},
+		},
+		{
+			name:    "MissingClientCertificate",
+			url:     mtlsServer.URL,
+			config:  HTTPClientConfig{CACertPEM: string(mtlsCACertPEM)},
+			wantErr: true,
+		},
+		{
+			name: "ClientCertificatePEM",
+			url:  mtlsServer.URL,
+			config: HTTPClientConfig{
+				CACertPEM:  string(mtlsCACertPEM),
+				ClientCert: string(clientCertPEM),
+				ClientKey:  string(clientKeyPEM),
+			},
+		},
+		{
+			name: "ClientCertificateFile",
+			url:  mtlsServer.URL,
+			config: HTTPClientConfig{
+				CACertPEM:  string(mtlsCACertPEM),
+				ClientCert: writeTempFile(t, "client.pem", clientCertPEM),
+				ClientKey:  writeTempFile(t, "client-key.pem", clientKeyPEM),
+			},
+		},
+		{
+			name:          "InvalidCACertPEM",
+			config:        HTTPClientConfig{CACertPEM: "not a certificate"},
+			wantConfigErr: true,
+		},
+		{
+			name:          "MissingCACertFile",
+			config:        HTTPClientConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+			wantConfigErr: true,
+		},
+		{
+			name:          "ClientCertificateWithoutKey",
+			config:        HTTPClientConfig{ClientCert: string(clientCertPEM)},
+			wantConfigErr: true,
+		},
+		{
+			name:          "MismatchedClientKey",
+			config:        HTTPClientConfig{ClientCert: string(clientCertPEM), ClientKey: string(caCertPEM)},
+			wantConfigErr: true,
+		},
+		{
+			name:          "InvalidProxyURL",
+			config:        HTTPClientConfig{ProxyURL: "proxy.example.com"},
+			wantConfigErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewHTTPClient(tt.config)
+			if (err != nil) != tt.wantConfigErr {
+				t.Fatalf("NewHTTPClient() error = %v, wantConfigErr %v", err, tt.wantConfigErr)
+			}
+			if tt.wantConfigErr {
+				return
+			}
+
+			resp, err := client.Get(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("http.Client.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				resp.Body.Close()
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	t.Parallel()
+
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	client, err := NewHTTPClient(HTTPClientConfig{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	resp, err := client.Get("http://api.ngc.example.com/v2/orgs")
+	if err != nil {
+		t.Fatalf("http.Client.Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	assert.Equal(t, "http://api.ngc.example.com/v2/orgs", proxiedURL)
+}
@@ -0,0 +1,166 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultNGCCLIProfile is the section the NGC CLI writes its settings to.
+const DefaultNGCCLIProfile = "CURRENT"
+
+// NGC CLI placeholders meaning the setting is not set.
+var ngcCLIUnsetValues = map[string]bool{
+	"":        true,
+	"no-org":  true,
+	"no-team": true,
+	"no-ace":  true,
+}
+
+// NGCCLIProfile holds the settings of one section of the NGC CLI config file.
+type NGCCLIProfile struct {
+	Name   string
+	ApiKey string
+	Org    string
+	Team   string
+	Format string
+}
+
+// NGCCLIConfigError reports a malformed NGC CLI config file.
+type NGCCLIConfigError struct {
+	Path    string
+	Line    int
+	Message string
+}
+
+func (e *NGCCLIConfigError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// DefaultNGCCLIConfigPath returns the config file written by the NGC CLI,
+// $NGC_CLI_HOME/config when set, ~/.ngc/config otherwise.
+func DefaultNGCCLIConfigPath() (string, error) {
+	if ngcCLIHome := os.Getenv("NGC_CLI_HOME"); ngcCLIHome != "" {
+		return filepath.Join(ngcCLIHome, "config"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ngc", "config"), nil
+}
+
+// ParseNGCCLIConfig parses the INI-style NGC CLI config into its profiles, keyed by section name.
+func ParseNGCCLIConfig(r io.Reader, path string) (map[string]NGCCLIProfile, error) {
+	profiles := make(map[string]NGCCLIProfile)
+	var current *NGCCLIProfile
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, ";") || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") || len(text) < 3 {
+				return nil, &NGCCLIConfigError{Path: path, Line: line, Message: fmt.Sprintf("malformed section header %q", text)}
+			}
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			if _, ok := profiles[name]; ok {
+				return nil, &NGCCLIConfigError{Path: path, Line: line, Message: fmt.Sprintf("duplicate profile %q", name)}
+			}
+			profiles[name] = NGCCLIProfile{Name: name}
+			profile := profiles[name]
+			current = &profile
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, &NGCCLIConfigError{Path: path, Line: line, Message: fmt.Sprintf("expected \"key = value\", got %q", text)}
+		}
+		if current == nil {
+			return nil, &NGCCLIConfigError{Path: path, Line: line, Message: "setting found before any [profile] section"}
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if ngcCLIUnsetValues[value] {
+			value = ""
+		}
+
+		switch key {
+		case "apikey":
+			current.ApiKey = value
+		case "org":
+			current.Org = value
+		case "team":
+			current.Team = value
+		case "format_type":
+			current.Format = value
+		}
+		profiles[current.Name] = *current
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, &NGCCLIConfigError{Path: path, Message: err.Error()}
+	}
+
+	return profiles, nil
+}
+
+// LoadNGCCLIProfile reads the named profile from the NGC CLI config file. A
+// missing file is only reported through os.ErrNotExist, so that callers can
+// ignore it when no profile was explicitly requested.
+func LoadNGCCLIProfile(path string, name string) (*NGCCLIProfile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles, err := ParseNGCCLIConfig(f, path)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for n := range profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, &NGCCLIConfigError{Path: path, Message: fmt.Sprintf("profile %q not found, available profiles: %q", name, names)}
+	}
+
+	return &profile, nil
+}
+
+// IsNGCCLIConfigNotFound reports whether the error is due to a missing config file.
+func IsNGCCLIConfigNotFound(err error) bool {
+	return errors.Is(err, os.ErrNotExist)
+}
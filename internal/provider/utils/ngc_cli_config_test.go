@@ -0,0 +1,122 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mockNGCCLIConfig = `;WARNING - This is a machine generated file.  Do not edit manually.
+;WARNING - To update local config settings, see "ngc config set -h"
+
+[CURRENT]
+apikey = mock-current-key
+format_type = ascii
+org = mock-org
+team = no-team
+ace = no-ace
+
+[staging]
+apikey = mock-staging-key
+org = mock-staging-org
+team = mock-staging-team
+`
+
+func TestParseNGCCLIConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		config       string
+		wantProfiles map[string]NGCCLIProfile
+		wantErrLine  int
+	}{
+		{
+			name:   "Profiles",
+			config: mockNGCCLIConfig,
+			wantProfiles: map[string]NGCCLIProfile{
+				"CURRENT": {Name: "CURRENT", ApiKey: "mock-current-key", Org: "mock-org", Format: "ascii"},
+				"staging": {Name: "staging", ApiKey: "mock-staging-key", Org: "mock-staging-org", Team: "mock-staging-team"},
+			},
+		},
+		{
+			name:         "Empty",
+			config:       "",
+			wantProfiles: map[string]NGCCLIProfile{},
+		},
+		{
+			name:        "SettingBeforeSection",
+			config:      "apikey = mock\n[CURRENT]\n",
+			wantErrLine: 1,
+		},
+		{
+			name:        "MalformedSection",
+			config:      "[CURRENT]\napikey = mock\n[staging\n",
+			wantErrLine: 3,
+		},
+		{
+			name:        "MissingEqual",
+			config:      "[CURRENT]\napikey mock\n",
+			wantErrLine: 2,
+		},
+		{
+			name:        "DuplicateProfile",
+			config:      "[CURRENT]\n[CURRENT]\n",
+			wantErrLine: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles, err := ParseNGCCLIConfig(strings.NewReader(tt.config), "mock-config")
+
+			if tt.wantErrLine > 0 {
+				var configErr *NGCCLIConfigError
+				if !errors.As(err, &configErr) {
+					t.Fatalf("ParseNGCCLIConfig() error = %v, want *NGCCLIConfigError", err)
+				}
+				assert.Equal(t, tt.wantErrLine, configErr.Line)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseNGCCLIConfig() error = %v", err)
+			}
+			assert.Equal(t, tt.wantProfiles, profiles)
+		})
+	}
+}
+
+func TestLoadNGCCLIProfile(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeTempFile(t, "config", []byte(mockNGCCLIConfig))
+
+	profile, err := LoadNGCCLIProfile(configPath, "staging")
+	if err != nil {
+		t.Fatalf("LoadNGCCLIProfile() error = %v", err)
+	}
+	assert.Equal(t, "mock-staging-key", profile.ApiKey)
+
+	_, err = LoadNGCCLIProfile(configPath, "production")
+	assert.EqualError(t, err, configPath+`: profile "production" not found, available profiles: ["CURRENT" "staging"]`)
+	assert.False(t, IsNGCCLIConfigNotFound(err))
+
+	_, err = LoadNGCCLIProfile(filepath.Join(t.TempDir(), "config"), DefaultNGCCLIProfile)
+	assert.True(t, IsNGCCLIConfigNotFound(err))
+}
@@ -15,20 +15,39 @@ import (
 	"sync"
 )
 
+// NGCClient holds the settings of one provider configuration. Each instance
+// lazily builds its own API clients, so aliased providers never share them.
 type NGCClient struct {
-	NgcEndpoint string
-	NgcApiKey   string
-	NgcOrg      string
-	NgcTeam     string
-	HttpClient  *http.Client
-}
+	NgcEndpoint          string
+	NgcApiKey            string
+	NgcOrg               string
+	NgcTeam              string
+	HttpClient           *http.Client
+	RetryPolicy          RetryPolicy
+	DeploymentPollPolicy DeploymentPollPolicy
+	// RateLimiter is shared by every request of the configuration, nil disables rate limiting.
+	RateLimiter *RateLimiter
+	// TokenSource provides the API key or access token, NgcApiKey is used when nil.
+	TokenSource TokenSource
 
-var nvcfClient *NVCFClient = nil
-var nvcfClientOnce sync.Once
+	nvcfClient     *NVCFClient
+	nvcfClientOnce sync.Once
+}
 
+// NVCFClient returns the NVCF client of this configuration, it is safe for concurrent use.
 func (c *NGCClient) NVCFClient() *NVCFClient {
-	nvcfClientOnce.Do(func() {
-		nvcfClient = &NVCFClient{c.NgcEndpoint, c.NgcApiKey, c.NgcOrg, c.NgcTeam, c.HttpClient}
+	c.nvcfClientOnce.Do(func() {
+		c.nvcfClient = &NVCFClient{
+			NgcEndpoint:          c.NgcEndpoint,
+			NgcApiKey:            c.NgcApiKey,
+			NgcOrg:               c.NgcOrg,
+			NgcTeam:              c.NgcTeam,
+			HttpClient:           c.HttpClient,
+			RetryPolicy:          c.RetryPolicy,
+			DeploymentPollPolicy: c.DeploymentPollPolicy,
+			RateLimiter:          c.RateLimiter,
+			TokenSource:          c.TokenSource,
+		}
 	})
-	return nvcfClient
+	return c.nvcfClient
 }
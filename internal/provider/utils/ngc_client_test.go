@@ -14,9 +14,17 @@
 package utils
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNGCClient_NVCFClient(t *testing.T) {
@@ -69,3 +77,73 @@ func TestNGCClient_NVCFClient(t *testing.T) {
 		})
 	}
 }
+
+// newOrgServer only accepts the requests of the given org and API key.
+func newOrgServer(t *testing.T, org string, apiKey string, requests *int32, unexpected *int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		if r.Header.Get("Authorization") != "Bearer "+apiKey || !strings.HasPrefix(r.URL.Path, "/v2/orgs/"+org+"/") {
+			atomic.AddInt32(unexpected, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"function": %s}`, mockContainerBasedFunctionInfo)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNGCClient_NVCFClientPerConfiguration(t *testing.T) {
+	t.Parallel()
+
+	var stagingRequests, stagingUnexpected, prodRequests, prodUnexpected int32
+	stagingServer := newOrgServer(t, "staging-org", "staging-key", &stagingRequests, &stagingUnexpected)
+	prodServer := newOrgServer(t, "prod-org", "prod-key", &prodRequests, &prodUnexpected)
+
+	staging := &NGCClient{
+		NgcEndpoint: stagingServer.URL,
+		NgcApiKey:   "staging-key",
+		NgcOrg:      "staging-org",
+		HttpClient:  stagingServer.Client(),
+	}
+	prod := &NGCClient{
+		NgcEndpoint: prodServer.URL,
+		NgcApiKey:   "prod-key",
+		NgcOrg:      "prod-org",
+		HttpClient:  prodServer.Client(),
+	}
+
+	const requestsPerClient = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*requestsPerClient)
+	for i := 0; i < requestsPerClient; i++ {
+		for _, c := range []*NGCClient{staging, prod} {
+			wg.Add(1)
+			go func(c *NGCClient) {
+				defer wg.Done()
+				_, err := c.NVCFClient().GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+				errs <- err
+			}(c)
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(requestsPerClient), atomic.LoadInt32(&stagingRequests))
+	assert.Equal(t, int32(requestsPerClient), atomic.LoadInt32(&prodRequests))
+	assert.Zero(t, atomic.LoadInt32(&stagingUnexpected))
+	assert.Zero(t, atomic.LoadInt32(&prodUnexpected))
+
+	assert.Same(t, staging.NVCFClient(), staging.NVCFClient())
+	assert.NotSame(t, staging.NVCFClient(), prod.NVCFClient())
+	assert.Equal(t, "staging-key", staging.NVCFClient().NgcApiKey)
+	assert.Equal(t, "prod-key", prod.NVCFClient().NgcApiKey)
+}
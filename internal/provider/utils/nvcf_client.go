@@ -18,17 +18,25 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type NVCFClient struct {
-	NgcEndpoint string
-	NgcApiKey   string
-	NgcOrg      string
-	NgcTeam     string
-	HttpClient  *http.Client
+	NgcEndpoint          string
+	NgcApiKey            string
+	NgcOrg               string
+	NgcTeam              string
+	HttpClient           *http.Client
+	RetryPolicy          RetryPolicy
+	DeploymentPollPolicy DeploymentPollPolicy
+	// RateLimiter is shared by every request of the client, nil disables rate limiting.
+	RateLimiter *RateLimiter
+	// TokenSource provides the credential sent with each request,
+	// a StaticTokenSource of NgcApiKey is used when nil.
+	TokenSource TokenSource
 }
 
 func (c *NVCFClient) NvcfEndpoint(context.Context) string {
@@ -43,64 +51,117 @@ func (c *NVCFClient) HTTPClient(context.Context) *http.Client {
 	return c.HttpClient
 }
 
+func (c *NVCFClient) tokenSource() TokenSource {
+	if c.TokenSource == nil {
+		return StaticTokenSource(c.NgcApiKey)
+	}
+	return c.TokenSource
+}
+
+// doRequest sends the request, retrying transient failures according to the client RetryPolicy.
+// A 401 renews the credential of a non-static TokenSource once before the request is sent again.
+func (c *NVCFClient) doRequest(ctx context.Context, requestURL string, method string, payload []byte) (*http.Response, []byte, error) {
+	tokenSource := c.tokenSource()
+	_, apiKeyRenewed := tokenSource.(StaticTokenSource)
+
+	for attempt := 0; ; attempt++ {
+		apiKey, err := tokenSource.Token(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return nil, nil, err
+		}
+
+		var request *http.Request
+
+		if payload != nil {
+			request, _ = http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(payload))
+		} else {
+			request, _ = http.NewRequestWithContext(ctx, method, requestURL, http.NoBody)
+		}
+
+		request.Header.Set("Authorization", "Bearer "+apiKey)
+		request.Header.Set("Content-Type", "application/json")
+
+		var body []byte
+		response, err := c.HttpClient.Do(request)
+
+		if err == nil {
+			body, _ = io.ReadAll(response.Body)
+			response.Body.Close()
+			c.RateLimiter.Observe(ctx, response)
+		}
+
+		if err == nil && response.StatusCode == http.StatusUnauthorized && !apiKeyRenewed {
+			apiKeyRenewed = true
+			tokenSource.Invalidate()
+			tflog.Info(ctx, fmt.Sprintf("renewing the credential after a 401 from %s", requestURL))
+			// The renewal is not counted as a retry.
+			attempt--
+			continue
+		}
+
+		if !c.RetryPolicy.shouldRetry(ctx, attempt, method, response, err) {
+			return response, body, err
+		}
+
+		wait := c.RetryPolicy.backoff(attempt, response)
+		retryCtx := tflog.SetField(ctx, "attempt", attempt+1)
+		retryCtx = tflog.SetField(retryCtx, "wait", wait.String())
+		if err != nil {
+			retryCtx = tflog.SetField(retryCtx, "error", err.Error())
+		} else {
+			retryCtx = tflog.SetField(retryCtx, "response_status", response.Status)
+		}
+		tflog.Warn(retryCtx, fmt.Sprintf("retrying request to %s with method %s", requestURL, method))
+
+		if sleepErr := sleepWithContext(ctx, wait); sleepErr != nil {
+			if err != nil {
+				return nil, nil, err
+			}
+			return response, body, nil
+		}
+	}
+}
+
 func (c *NVCFClient) sendRequest(ctx context.Context, requestURL string, method string, requestBody any, responseObject any, expectedStatusCode map[int]bool) error {
-	var request *http.Request
+	var payload []byte
 
 	if requestBody != nil {
 		payloadBuf := new(bytes.Buffer)
 		err := json.NewEncoder(payloadBuf).Encode(requestBody)
 		if err != nil {
-			tflog.Error(ctx, fmt.Sprintf("failed to parse request body %s", requestBody))
+			tflog.Error(ctx, "failed to parse request body")
 			return err
 		}
-		request, _ = http.NewRequest(method, requestURL, payloadBuf)
-	} else {
-		request, _ = http.NewRequest(method, requestURL, http.NoBody)
+		payload = payloadBuf.Bytes()
 	}
 
-	request.Header.Set("Authorization", "Bearer "+c.NgcApiKey)
-	request.Header.Set("Content-Type", "application/json")
-
-	response, err := c.HttpClient.Do(request)
+	response, body, err := c.doRequest(ctx, requestURL, method, payload)
 
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("failed to send request to %s with method %s", requestURL, method))
 		return err
 	}
 
-	defer response.Body.Close()
-	body, _ := io.ReadAll(response.Body)
-
+	// Request and response bodies are logged, redacted, by the shared Transport.
 	ctx = tflog.SetField(ctx, "response_status", response.Status)
-	ctx = tflog.SetField(ctx, "response_header", response.Header)
-	ctx = tflog.SetField(ctx, "response_body", string(body))
-	ctx = tflog.SetField(ctx, "request_body", requestBody)
 
 	tflog.Debug(ctx, "Send request")
 
 	if _, ok := expectedStatusCode[response.StatusCode]; !ok {
-		tflog.Error(ctx, "got unexpected response code")
-
-		// The unauthenticated response format is different with others
-		if response.StatusCode == 401 {
-			tflog.Error(ctx, "unauthenticated error")
-			return errors.New("not authenticated")
-		}
+		apiErr := newAPIError(method, requestURL, response.StatusCode, body)
 
-		var errResponseObject = &ErrorResponse{}
-		err = json.Unmarshal(body, errResponseObject)
+		ctx = tflog.SetField(ctx, "nvcf_request_id", apiErr.RequestID)
+		tflog.Error(ctx, "got unexpected response code")
 
-		if err != nil {
-			ctx = tflog.SetField(ctx, "response_body", string(body))
-			tflog.Error(ctx, "failed to parse error response body")
-			return fmt.Errorf("failed to parse error response body. Response body: %s", string(body))
+		if apiErr.Body != "" {
+			tflog.Error(ctx, "no error message in the response body")
 		}
 
-		if errResponseObject.RequestStatus.StatusDescription != "" {
-			return errors.New(errResponseObject.RequestStatus.StatusDescription)
-		} else {
-			return errors.New(errResponseObject.Detail)
-		}
+		return apiErr
 	}
 
 	if responseObject != nil {
@@ -131,6 +192,21 @@ func (c *NVCFClient) CreateNvidiaCloudFunction(ctx context.Context, functionID s
 	return &createNvidiaCloudFunctionResponse, err
 }
 
+// ListNvidiaCloudFunctions lists every function version visible to the org/team.
+// Visibility accepts "private", "public" and "authorized", all of them are returned when empty.
+func (c *NVCFClient) ListNvidiaCloudFunctions(ctx context.Context, visibility []string) (resp *ListNvidiaCloudFunctionsResponse, err error) {
+	var listNvidiaCloudFunctionsResponse ListNvidiaCloudFunctionsResponse
+
+	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/functions"
+	if len(visibility) > 0 {
+		requestURL += "?" + url.Values{"visibility": visibility}.Encode()
+	}
+
+	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &listNvidiaCloudFunctionsResponse, map[int]bool{200: true})
+	tflog.Debug(ctx, "List NVCF Functions")
+	return &listNvidiaCloudFunctionsResponse, err
+}
+
 func (c *NVCFClient) ListNvidiaCloudFunctionVersions(ctx context.Context, functionID string) (resp *ListNvidiaCloudFunctionVersionsResponse, err error) {
 	var listNvidiaCloudFunctionVersionsResponse ListNvidiaCloudFunctionVersionsResponse
 
@@ -169,6 +245,15 @@ func (c *NVCFClient) DeleteNvidiaCloudFunctionVersion(ctx context.Context, funct
 	return err
 }
 
+// UpdateNvidiaCloudFunctionSecrets replaces the secrets of a function version in place, without creating a new version.
+func (c *NVCFClient) UpdateNvidiaCloudFunctionSecrets(ctx context.Context, functionID string, functionVersionID string, req UpdateNvidiaCloudFunctionSecretsRequest) (err error) {
+	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/secrets/functions/" + functionID + "/versions/" + functionVersionID
+
+	err = c.sendRequest(ctx, requestURL, http.MethodPut, req, nil, map[int]bool{200: true, 204: true})
+	tflog.Debug(ctx, "Update NVCF Function Secrets")
+	return err
+}
+
 // Function Deployment APIs.
 func (c *NVCFClient) CreateNvidiaCloudFunctionDeployment(ctx context.Context, functionID string, functionVersionID string, req CreateNvidiaCloudFunctionDeploymentRequest) (resp *CreateNvidiaCloudFunctionDeploymentResponse, err error) {
 	var createNvidiaCloudFunctionDeploymentResponse CreateNvidiaCloudFunctionDeploymentResponse
@@ -189,27 +274,198 @@ func (c *NVCFClient) UpdateNvidiaCloudFunctionDeployment(ctx context.Context, fu
 	return &updateNvidiaCloudFunctionDeploymentResponse, err
 }
 
+// WaitingDeploymentCompleted polls the deployment until it is ACTIVE. The wait
+// between polls grows exponentially following the DeploymentPollPolicy. Only the
+// ERROR and DEGRADED statuses fail the wait, the other ones, such as INACTIVE
+// right after the deployment is created, are polled again.
 func (c *NVCFClient) WaitingDeploymentCompleted(ctx context.Context, functionID string, functionVersionId string) error {
+	pollPolicy := c.DeploymentPollPolicy.withDefaults()
+	interval := pollPolicy.Interval
+	transientErrors := 0
+	progress := newDeploymentProgress(functionID, functionVersionId)
+
 	for {
 		readNvidiaCloudFunctionDeploymentResponse, err := c.ReadNvidiaCloudFunctionDeployment(ctx, functionID, functionVersionId)
 
 		if err != nil {
-			return err
+			if ctx.Err() != nil {
+				return progress.timeoutError()
+			}
+			transientErrors++
+			if !c.isTransientPollError(err) || transientErrors > pollPolicy.MaxTransientErrors {
+				return err
+			}
+			tflog.Warn(ctx, "Transient error while polling the function deployment", map[string]interface{}{
+				"error":            err.Error(),
+				"transient_errors": transientErrors,
+			})
+		} else {
+			transientErrors = 0
+			deployment := readNvidiaCloudFunctionDeploymentResponse.Deployment
+			progress.observeDeployment(ctx, deployment)
+
+			switch deployment.FunctionStatus {
+			case "ACTIVE":
+				return nil
+			case "ERROR", "DEGRADED":
+				return &DeploymentError{
+					FunctionID:        functionID,
+					FunctionVersionID: functionVersionId,
+					FunctionStatus:    deployment.FunctionStatus,
+					HealthInfo:        deployment.HealthInfo,
+				}
+			default:
+				// The instances are only reported on the function version.
+				getNvidiaCloudFunctionVersionResponse, err := c.GetNvidiaCloudFunctionVersion(ctx, functionID, functionVersionId)
+				if err != nil {
+					tflog.Debug(ctx, "Failed to read the function version instances", map[string]interface{}{"error": err.Error()})
+				} else {
+					progress.observeInstances(ctx, getNvidiaCloudFunctionVersionResponse.Function.ActiveInstances)
+				}
+			}
+		}
+
+		if err := sleepWithContext(ctx, interval); err != nil {
+			return progress.timeoutError()
+		}
+		interval = pollPolicy.nextInterval(interval)
+	}
+}
+
+// WaitingDeploymentScaled polls the deployment after an update until it is ACTIVE with the instances of the
+// given specifications, at least their min instances running and at most their max instances. The ACTIVE status
+// from before the update is not mistaken for its completion. Only the ERROR and DEGRADED statuses fail the wait,
+// the other ones, such as INACTIVE while the instances are replaced, are polled again.
+func (c *NVCFClient) WaitingDeploymentScaled(ctx context.Context, functionID string, functionVersionId string, specifications []NvidiaCloudFunctionDeploymentSpecification) error {
+	pollPolicy := c.DeploymentPollPolicy.withDefaults()
+	interval := pollPolicy.Interval
+	transientErrors := 0
+	progress := newDeploymentProgress(functionID, functionVersionId)
+
+	minInstances, maxInstances := 0, 0
+	for _, spec := range specifications {
+		minInstances += spec.MinInstances
+		maxInstances += spec.MaxInstances
+	}
+
+	for {
+		scaled, err := c.deploymentScaled(ctx, functionID, functionVersionId, minInstances, maxInstances, progress)
+
+		if err != nil {
+			var deploymentErr *DeploymentError
+			if errors.As(err, &deploymentErr) {
+				return err
+			}
+			if ctx.Err() != nil {
+				return progress.timeoutError()
+			}
+			transientErrors++
+			if !c.isTransientPollError(err) || transientErrors > pollPolicy.MaxTransientErrors {
+				return err
+			}
+			tflog.Warn(ctx, "Transient error while polling the function deployment", map[string]interface{}{
+				"error":            err.Error(),
+				"transient_errors": transientErrors,
+			})
+		} else {
+			transientErrors = 0
+			if scaled {
+				return nil
+			}
 		}
 
-		if readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus == "ACTIVE" {
-			return nil
-		} else if readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus == "DEPLOYING" {
-			select {
-			case <-ctx.Done():
-				return errors.New("timeout occurred")
-			case <-time.After(60 * time.Second):
-				continue
+		if err := sleepWithContext(ctx, interval); err != nil {
+			return progress.timeoutError()
+		}
+		interval = pollPolicy.nextInterval(interval)
+	}
+}
+
+// deploymentScaled reads the deployment and its instances, and reports whether it is ACTIVE with
+// at least minInstances running and at most maxInstances.
+func (c *NVCFClient) deploymentScaled(ctx context.Context, functionID string, functionVersionId string, minInstances int, maxInstances int, progress *deploymentProgress) (bool, error) {
+	readNvidiaCloudFunctionDeploymentResponse, err := c.ReadNvidiaCloudFunctionDeployment(ctx, functionID, functionVersionId)
+	if err != nil {
+		return false, err
+	}
+
+	deployment := readNvidiaCloudFunctionDeploymentResponse.Deployment
+	progress.observeDeployment(ctx, deployment)
+
+	switch deployment.FunctionStatus {
+	case "ERROR", "DEGRADED":
+		return false, &DeploymentError{
+			FunctionID:        functionID,
+			FunctionVersionID: functionVersionId,
+			FunctionStatus:    deployment.FunctionStatus,
+			HealthInfo:        deployment.HealthInfo,
+		}
+	}
+
+	// The instances are only reported on the function version.
+	getNvidiaCloudFunctionVersionResponse, err := c.GetNvidiaCloudFunctionVersion(ctx, functionID, functionVersionId)
+	if err != nil {
+		return false, err
+	}
+
+	activeInstances := getNvidiaCloudFunctionVersionResponse.Function.ActiveInstances
+	progress.observeInstances(ctx, activeInstances)
+
+	return deployment.FunctionStatus == "ACTIVE" &&
+		runningInstancesCount(activeInstances) >= minInstances &&
+		len(activeInstances) <= maxInstances, nil
+}
+
+// WaitingDeploymentDeleted polls the deployment after its deletion until it is gone, so that the function
+// version can be deployed again without racing the teardown of its instances.
+func (c *NVCFClient) WaitingDeploymentDeleted(ctx context.Context, functionID string, functionVersionId string) error {
+	pollPolicy := c.DeploymentPollPolicy.withDefaults()
+	interval := pollPolicy.Interval
+	transientErrors := 0
+	startedAt := time.Now()
+	functionStatus := ""
+
+	for {
+		readNvidiaCloudFunctionDeploymentResponse, err := c.ReadNvidiaCloudFunctionDeployment(ctx, functionID, functionVersionId)
+
+		if err != nil {
+			if IsNotFound(err) {
+				return nil
+			}
+			if ctx.Err() != nil {
+				break
 			}
+			transientErrors++
+			if !c.isTransientPollError(err) || transientErrors > pollPolicy.MaxTransientErrors {
+				return err
+			}
+			tflog.Warn(ctx, "Transient error while polling the function deployment", map[string]interface{}{
+				"error":            err.Error(),
+				"transient_errors": transientErrors,
+			})
 		} else {
-			return fmt.Errorf("unexpected status %s", readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus)
+			transientErrors = 0
+			// A 404 is not an error of the read, it leaves the deployment empty.
+			functionStatus = readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus
+			if functionStatus == "" {
+				return nil
+			}
+			tflog.Info(ctx, "Waiting for function deployment deletion", map[string]interface{}{
+				"function_id": functionID,
+				"version_id":  functionVersionId,
+				"status":      functionStatus,
+				"elapsed":     time.Since(startedAt).Round(time.Second).String(),
+			})
+		}
+
+		if err := sleepWithContext(ctx, interval); err != nil {
+			break
 		}
+		interval = pollPolicy.nextInterval(interval)
 	}
+
+	return fmt.Errorf("timeout occurred after %s waiting for the deletion of the deployment of function version %s, last status %s",
+		time.Since(startedAt).Round(time.Second), functionVersionId, functionStatus)
 }
 
 func (c *NVCFClient) ReadNvidiaCloudFunctionDeployment(ctx context.Context, functionID string, functionVersionID string) (resp *ReadNvidiaCloudFunctionDeploymentResponse, err error) {
@@ -232,30 +488,122 @@ func (c *NVCFClient) DeleteNvidiaCloudFunctionDeployment(ctx context.Context, fu
 }
 
 // Function Sharing APIs.
+
+// AuthorizeAccountsToInvokeFunction replaces the parties authorized to invoke the function version.
 func (c *NVCFClient) AuthorizeAccountsToInvokeFunction(ctx context.Context, functionID string, functionVersionID string, req AuthorizeAccountsToInvokeFunctionRequest) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
-	var authorizeAccountsToInvokeFunctionResponse AuthorizeAccountsToInvokeFunctionResponse
+	requestURL, err := c.functionVersionAuthorizationsURL(ctx, functionID, functionVersionID)
+	if err != nil {
+		return &AuthorizeAccountsToInvokeFunctionResponse{}, err
+	}
+	return c.authorizeAccounts(ctx, requestURL, req)
+}
+
+// AuthorizeAccountsToInvokeAllFunctionVersions replaces the parties authorized to invoke the function itself,
+// whatever its version.
+func (c *NVCFClient) AuthorizeAccountsToInvokeAllFunctionVersions(ctx context.Context, functionID string, req AuthorizeAccountsToInvokeFunctionRequest) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
+	return c.authorizeAccounts(ctx, c.functionAuthorizationsURL(ctx, functionID), req)
+}
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/authorizations/functions/" + functionID + "/versions/" + functionVersionID
+func (c *NVCFClient) authorizeAccounts(ctx context.Context, requestURL string, req AuthorizeAccountsToInvokeFunctionRequest) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
+	var authorizeAccountsToInvokeFunctionResponse AuthorizeAccountsToInvokeFunctionResponse
 
 	err = c.sendRequest(ctx, requestURL, http.MethodPost, req, &authorizeAccountsToInvokeFunctionResponse, map[int]bool{200: true})
 	tflog.Debug(ctx, "Authorize Accounts To Invoke Function")
 	return &authorizeAccountsToInvokeFunctionResponse, err
 }
 
+// UnAuthorizeAllExtraAccountsToInvokeFunction unauthorizes every party authorized to invoke the function version.
 func (c *NVCFClient) UnAuthorizeAllExtraAccountsToInvokeFunction(ctx context.Context, functionID string, functionVersionID string) (err error) {
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/authorizations/functions/" + functionID + "/versions/" + functionVersionID
+	requestURL, err := c.functionVersionAuthorizationsURL(ctx, functionID, functionVersionID)
+	if err != nil {
+		return err
+	}
+	return c.unauthorizeAllAccounts(ctx, requestURL)
+}
 
+// UnAuthorizeAllExtraAccountsToInvokeAllFunctionVersions unauthorizes every party authorized to invoke the function
+// itself, the parties authorized to invoke one of its versions keeping their access.
+func (c *NVCFClient) UnAuthorizeAllExtraAccountsToInvokeAllFunctionVersions(ctx context.Context, functionID string) (err error) {
+	return c.unauthorizeAllAccounts(ctx, c.functionAuthorizationsURL(ctx, functionID))
+}
+
+func (c *NVCFClient) unauthorizeAllAccounts(ctx context.Context, requestURL string) (err error) {
 	err = c.sendRequest(ctx, requestURL, http.MethodDelete, nil, nil, map[int]bool{200: true})
 	tflog.Debug(ctx, "Unauthorize All Extra Accounts To Invoke Function")
 	return err
 }
 
+// GetFunctionAuthorization returns the parties authorized to invoke the function version.
 func (c *NVCFClient) GetFunctionAuthorization(ctx context.Context, functionID string, functionVersionID string) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
-	var authorizeAccountsToInvokeFunctionResponse AuthorizeAccountsToInvokeFunctionResponse
+	requestURL, err := c.functionVersionAuthorizationsURL(ctx, functionID, functionVersionID)
+	if err != nil {
+		return &AuthorizeAccountsToInvokeFunctionResponse{}, err
+	}
+	return c.getAuthorization(ctx, requestURL)
+}
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/authorizations/functions/" + functionID + "/versions/" + functionVersionID
+// GetAllFunctionVersionsAuthorization returns the parties authorized to invoke the function itself, whatever its version.
+func (c *NVCFClient) GetAllFunctionVersionsAuthorization(ctx context.Context, functionID string) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
+	return c.getAuthorization(ctx, c.functionAuthorizationsURL(ctx, functionID))
+}
+
+func (c *NVCFClient) getAuthorization(ctx context.Context, requestURL string) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
+	var authorizeAccountsToInvokeFunctionResponse AuthorizeAccountsToInvokeFunctionResponse
 
 	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &authorizeAccountsToInvokeFunctionResponse, map[int]bool{200: true})
 	tflog.Debug(ctx, "Get Function Authorization")
 	return &authorizeAccountsToInvokeFunctionResponse, err
 }
+
+// AddAuthorizedParty authorizes one more party to invoke the function version, keeping the parties already authorized.
+func (c *NVCFClient) AddAuthorizedParty(ctx context.Context, functionID string, functionVersionID string, party AuthorizedParty) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
+	requestURL, err := c.functionVersionAuthorizationsURL(ctx, functionID, functionVersionID)
+	if err != nil {
+		return &AuthorizeAccountsToInvokeFunctionResponse{}, err
+	}
+	return c.patchAuthorizedParty(ctx, requestURL+"/add", party)
+}
+
+// AddAllFunctionVersionsAuthorizedParty authorizes one more party to invoke the function itself, whatever its
+// version, keeping the parties already authorized.
+func (c *NVCFClient) AddAllFunctionVersionsAuthorizedParty(ctx context.Context, functionID string, party AuthorizedParty) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
+	return c.patchAuthorizedParty(ctx, c.functionAuthorizationsURL(ctx, functionID)+"/add", party)
+}
+
+// RemoveAuthorizedParty stops one party from invoking the function version, keeping the other parties.
+func (c *NVCFClient) RemoveAuthorizedParty(ctx context.Context, functionID string, functionVersionID string, party AuthorizedParty) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
+	requestURL, err := c.functionVersionAuthorizationsURL(ctx, functionID, functionVersionID)
+	if err != nil {
+		return &AuthorizeAccountsToInvokeFunctionResponse{}, err
+	}
+	return c.patchAuthorizedParty(ctx, requestURL+"/remove", party)
+}
+
+// RemoveAllFunctionVersionsAuthorizedParty stops one party from invoking the function itself, keeping the other
+// parties. The party keeps invoking the versions it is authorized on.
+func (c *NVCFClient) RemoveAllFunctionVersionsAuthorizedParty(ctx context.Context, functionID string, party AuthorizedParty) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
+	return c.patchAuthorizedParty(ctx, c.functionAuthorizationsURL(ctx, functionID)+"/remove", party)
+}
+
+// patchAuthorizedParty adds or removes one party with the /add or /remove authorizations endpoint.
+func (c *NVCFClient) patchAuthorizedParty(ctx context.Context, requestURL string, party AuthorizedParty) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
+	var authorizeAccountsToInvokeFunctionResponse AuthorizeAccountsToInvokeFunctionResponse
+
+	err = c.sendRequest(ctx, requestURL, http.MethodPatch, AuthorizedPartyRequest{AuthorizedParty: party}, &authorizeAccountsToInvokeFunctionResponse, map[int]bool{200: true})
+	tflog.Debug(ctx, "Patch Function Authorized Party")
+	return &authorizeAccountsToInvokeFunctionResponse, err
+}
+
+// functionAuthorizationsURL is the authorizations endpoint of the function, for the parties invoking any of its versions.
+func (c *NVCFClient) functionAuthorizationsURL(ctx context.Context, functionID string) string {
+	return c.NvcfEndpoint(ctx) + "/nvcf/authorizations/functions/" + functionID
+}
+
+// functionVersionAuthorizationsURL is the authorizations endpoint of the function version. An empty version ID is
+// rejected rather than sent to the endpoint of the function, which would change the parties of every version.
+func (c *NVCFClient) functionVersionAuthorizationsURL(ctx context.Context, functionID string, functionVersionID string) (string, error) {
+	if functionVersionID == "" {
+		return "", fmt.Errorf("missing version ID to authorize parties on a version of function %s", functionID)
+	}
+	return c.functionAuthorizationsURL(ctx, functionID) + "/versions/" + functionVersionID, nil
+}
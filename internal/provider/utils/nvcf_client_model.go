@@ -19,6 +19,8 @@ type ErrorResponse struct {
 	Status   int    `json:"status"`
 	Detail   string `json:"detail"`
 	Instance string `json:"instance"`
+	// Gateways in front of NVCF reply with a message only.
+	Message string `json:"message"`
 }
 
 type NvidiaCloudFunctionSecret struct {
@@ -117,6 +119,10 @@ type CreateNvidiaCloudFunctionResponse struct {
 	Function NvidiaCloudFunctionInfo `json:"function"`
 }
 
+type ListNvidiaCloudFunctionsResponse struct {
+	Functions []NvidiaCloudFunctionInfo `json:"functions"`
+}
+
 type ListNvidiaCloudFunctionVersionsResponse struct {
 	Functions []NvidiaCloudFunctionInfo `json:"functions"`
 }
@@ -137,6 +143,10 @@ type UpdateNvidiaCloudFunctionMetadataResponse struct {
 	Function NvidiaCloudFunctionInfo `json:"function"`
 }
 
+type UpdateNvidiaCloudFunctionSecretsRequest struct {
+	Secrets []NvidiaCloudFunctionSecret `json:"secrets"`
+}
+
 type NvidiaCloudFunctionDeploymentSpecification struct {
 	Gpu                   string      `json:"gpu"`
 	Backend               string      `json:"backend"`
@@ -189,10 +199,14 @@ type AuthorizeAccountsToInvokeFunctionRequest struct {
 	AuthorizedParties []AuthorizedParty `json:"authorizedParties"`
 }
 
+type AuthorizedPartyRequest struct {
+	AuthorizedParty AuthorizedParty `json:"authorizedParty"`
+}
+
 type AuthorizeAccountsToInvokeFunctionResponseFunctionInfo struct {
 	Id                string            `json:"id"`
 	NcaID             string            `json:"ncaId"`
-	VersionID         string            `json:"versionId"`
+	VersionID         string            `json:"versionId,omitempty"`
 	AuthorizedParties []AuthorizedParty `json:"authorizedParties"`
 }
 
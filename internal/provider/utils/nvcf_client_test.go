@@ -111,7 +111,7 @@ var mockFunctionDeploymentFailedInfo = fmt.Sprintf(
 			"functionId": "%s",
 			"functionVersionID": "%s",
 			"ncaId": "SfDTycz_Y81Iq7rCtGXj4gy93huIjvzQ3ZtNvumZywg",
-			"functionStatus": "FAILED",
+			"functionStatus": "ERROR",
 			"requestQueueUrl": "https://sqs.us-west-2.amazonaws.com/052277528122/gdn-strap-dynamic_SfDTycz-Y81Iq7rCt_6cf20357-b6c9-459e-ae36-34b22319b7e4.fifo",
 			"deploymentSpecifications": [%s]
 		}
@@ -568,6 +568,110 @@ func TestNVCFClient_CreateNvidiaCloudFunction(t *testing.T) {
 	}
 }
 
+func TestNVCFClient_ListNvidiaCloudFunctions(t *testing.T) {
+	t.Parallel()
+
+	listNvidiaCloudFunctionsMockRespRaw := fmt.Sprintf(`
+		{
+			"functions": [%s, %s]
+		}
+		`,
+		mockContainerBasedFunctionInfo,
+		mockHelmBasedFunctionInfo)
+	var listNvidiaCloudFunctionsMockResp ListNvidiaCloudFunctionsResponse
+	json.Unmarshal([]byte(listNvidiaCloudFunctionsMockRespRaw), &listNvidiaCloudFunctionsMockResp)
+
+	type fields struct {
+		NgcEndpoint string
+		NgcApiKey   string
+		NgcOrg      string
+		NgcTeam     string
+		HttpClient  *http.Client
+	}
+	type args struct {
+		ctx        context.Context
+		visibility []string
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantResp *ListNvidiaCloudFunctionsResponse
+		wantErr  bool
+	}{
+		{
+			name: "ListNvidiaCloudFunctions",
+			fields: fields{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(
+						t,
+						fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/functions?visibility=private&visibility=authorized", mockEndpoint, mockOrg, mockTeam),
+						http.MethodGet,
+						nvcfRequestHeaders,
+						nil,
+						listNvidiaCloudFunctionsMockRespRaw,
+						200,
+					),
+				},
+			},
+			args: args{
+				ctx:        context.Background(),
+				visibility: []string{"private", "authorized"},
+			},
+			wantResp: &listNvidiaCloudFunctionsMockResp,
+			wantErr:  false,
+		},
+		{
+			name: "ListNvidiaCloudFunctionsFailed",
+			fields: fields{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(
+						t,
+						fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/functions", mockEndpoint, mockOrg, mockTeam),
+						http.MethodGet,
+						nvcfRequestHeaders,
+						nil,
+						mockErrorResponse,
+						500,
+					),
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantResp: &ListNvidiaCloudFunctionsResponse{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: tt.fields.NgcEndpoint,
+				NgcApiKey:   tt.fields.NgcApiKey,
+				NgcOrg:      tt.fields.NgcOrg,
+				NgcTeam:     tt.fields.NgcTeam,
+				HttpClient:  tt.fields.HttpClient,
+			}
+			gotResp, err := c.ListNvidiaCloudFunctions(tt.args.ctx, tt.args.visibility)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NVCFClient.ListNvidiaCloudFunctions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResp, tt.wantResp) {
+				t.Errorf("NVCFClient.ListNvidiaCloudFunctions() = %v, want %v", gotResp, tt.wantResp)
+			}
+		})
+	}
+}
+
 func TestNVCFClient_ListNvidiaCloudFunctionVersions(t *testing.T) {
 	t.Parallel()
 
@@ -763,6 +867,106 @@ func TestNVCFClient_DeleteNvidiaCloudFunctionVersion(t *testing.T) {
 	}
 }
 
+func TestNVCFClient_UpdateNvidiaCloudFunctionSecrets(t *testing.T) {
+	t.Parallel()
+
+	var updateNvidiaCloudFunctionSecretsReq = UpdateNvidiaCloudFunctionSecretsRequest{
+		Secrets: []NvidiaCloudFunctionSecret{
+			{Name: "DB_PASSWORD", Value: "rotated"},
+			{Name: "CONFIG", Value: map[string]interface{}{"key": "value"}},
+		},
+	}
+
+	type fields struct {
+		NgcEndpoint string
+		NgcApiKey   string
+		NgcOrg      string
+		NgcTeam     string
+		HttpClient  *http.Client
+	}
+	type args struct {
+		ctx               context.Context
+		functionID        string
+		functionVersionID string
+		req               UpdateNvidiaCloudFunctionSecretsRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "UpdateNvidiaCloudFunctionSecrets",
+			fields: fields{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(
+						t,
+						fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/secrets/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+						http.MethodPut,
+						nvcfRequestHeaders,
+						updateNvidiaCloudFunctionSecretsReq,
+						"",
+						204,
+					),
+				},
+			},
+			args: args{
+				ctx:               context.Background(),
+				functionID:        mockFunctionID,
+				functionVersionID: mockVersionID,
+				req:               updateNvidiaCloudFunctionSecretsReq,
+			},
+			wantErr: false,
+		},
+		{
+			name: "UpdateNvidiaCloudFunctionSecretsFailed",
+			fields: fields{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(
+						t,
+						fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/secrets/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+						http.MethodPut,
+						nvcfRequestHeaders,
+						updateNvidiaCloudFunctionSecretsReq,
+						mockErrorResponse,
+						400,
+					),
+				},
+			},
+			args: args{
+				ctx:               context.Background(),
+				functionID:        mockFunctionID,
+				functionVersionID: mockVersionID,
+				req:               updateNvidiaCloudFunctionSecretsReq,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: tt.fields.NgcEndpoint,
+				NgcApiKey:   tt.fields.NgcApiKey,
+				NgcOrg:      tt.fields.NgcOrg,
+				NgcTeam:     tt.fields.NgcTeam,
+				HttpClient:  tt.fields.HttpClient,
+			}
+			if err := c.UpdateNvidiaCloudFunctionSecrets(tt.args.ctx, tt.args.functionID, tt.args.functionVersionID, tt.args.req); (err != nil) != tt.wantErr {
+				t.Errorf("NVCFClient.UpdateNvidiaCloudFunctionSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestNVCFClient_CreateNvidiaCloudFunctionDeployment(t *testing.T) {
 	t.Parallel()
 
@@ -1306,3 +1510,303 @@ func TestNVCFClient_DeleteNvidiaCloudFunctionDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestNVCFClient_AddAuthorizedParty(t *testing.T) {
+	t.Parallel()
+
+	var party = AuthorizedParty{NcaID: "MOCK_NCA_ID", ClientId: "MOCK_CLIENT_ID"}
+	var authorizedPartyResponse = `{"function": {"id": "%s", "ncaId": "MOCK_NCA_ID", "authorizedParties": [{"ncaId": "MOCK_NCA_ID", "clientId": "MOCK_CLIENT_ID"}]}}`
+
+	type args struct {
+		functionID        string
+		functionVersionID string
+		allVersions       bool
+	}
+	tests := []struct {
+		name       string
+		args       args
+		target     string
+		resp       string
+		respCode   int
+		wantErr    bool
+		wantResult []AuthorizedParty
+	}{
+		{
+			name:       "FunctionVersion",
+			args:       args{functionID: mockFunctionID, functionVersionID: mockVersionID},
+			target:     fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s/versions/%s/add", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+			resp:       fmt.Sprintf(authorizedPartyResponse, mockFunctionID),
+			respCode:   200,
+			wantResult: []AuthorizedParty{party},
+		},
+		{
+			name:       "Function",
+			args:       args{functionID: mockFunctionID, allVersions: true},
+			target:     fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s/add", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			resp:       fmt.Sprintf(authorizedPartyResponse, mockFunctionID),
+			respCode:   200,
+			wantResult: []AuthorizedParty{party},
+		},
+		{
+			name:    "MissingVersionID",
+			args:    args{functionID: mockFunctionID},
+			target:  fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s/add", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			wantErr: true,
+		},
+		{
+			name:     "Failed",
+			args:     args{functionID: mockFunctionID, functionVersionID: mockVersionID},
+			target:   fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s/versions/%s/add", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+			resp:     mockErrorResponse,
+			respCode: 400,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(t, tt.target, http.MethodPatch, nvcfRequestHeaders, AuthorizedPartyRequest{AuthorizedParty: party}, tt.resp, tt.respCode),
+				},
+			}
+			var got *AuthorizeAccountsToInvokeFunctionResponse
+			var err error
+			if tt.args.allVersions {
+				got, err = c.AddAllFunctionVersionsAuthorizedParty(context.Background(), tt.args.functionID, party)
+			} else {
+				got, err = c.AddAuthorizedParty(context.Background(), tt.args.functionID, tt.args.functionVersionID, party)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NVCFClient.AddAuthorizedParty() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantResult, got.Function.AuthorizedParties)
+			}
+		})
+	}
+}
+
+func TestNVCFClient_RemoveAuthorizedParty(t *testing.T) {
+	t.Parallel()
+
+	var party = AuthorizedParty{NcaID: "MOCK_NCA_ID"}
+
+	type args struct {
+		functionID        string
+		functionVersionID string
+		allVersions       bool
+	}
+	tests := []struct {
+		name     string
+		args     args
+		target   string
+		resp     string
+		respCode int
+		wantErr  bool
+	}{
+		{
+			name:     "FunctionVersion",
+			args:     args{functionID: mockFunctionID, functionVersionID: mockVersionID},
+			target:   fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s/versions/%s/remove", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+			resp:     fmt.Sprintf(`{"function": {"id": "%s", "authorizedParties": []}}`, mockFunctionID),
+			respCode: 200,
+		},
+		{
+			name:     "Function",
+			args:     args{functionID: mockFunctionID, allVersions: true},
+			target:   fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s/remove", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			resp:     fmt.Sprintf(`{"function": {"id": "%s", "authorizedParties": []}}`, mockFunctionID),
+			respCode: 200,
+		},
+		{
+			name:    "MissingVersionID",
+			args:    args{functionID: mockFunctionID},
+			target:  fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s/remove", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			wantErr: true,
+		},
+		{
+			name:     "Failed",
+			args:     args{functionID: mockFunctionID, allVersions: true},
+			target:   fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s/remove", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			resp:     mockErrorResponse,
+			respCode: 400,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(t, tt.target, http.MethodPatch, nvcfRequestHeaders, AuthorizedPartyRequest{AuthorizedParty: party}, tt.resp, tt.respCode),
+				},
+			}
+			var err error
+			if tt.args.allVersions {
+				_, err = c.RemoveAllFunctionVersionsAuthorizedParty(context.Background(), tt.args.functionID, party)
+			} else {
+				_, err = c.RemoveAuthorizedParty(context.Background(), tt.args.functionID, tt.args.functionVersionID, party)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NVCFClient.RemoveAuthorizedParty() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNVCFClient_AuthorizeAccountsToInvokeFunction(t *testing.T) {
+	t.Parallel()
+
+	var request = AuthorizeAccountsToInvokeFunctionRequest{AuthorizedParties: []AuthorizedParty{{NcaID: "MOCK_NCA_ID"}}}
+	var authorizeResponse = `{"function": {"id": "%s", "ncaId": "MOCK_NCA_ID", "authorizedParties": [{"ncaId": "MOCK_NCA_ID"}]}}`
+
+	type args struct {
+		functionID        string
+		functionVersionID string
+		allVersions       bool
+	}
+	tests := []struct {
+		name       string
+		args       args
+		target     string
+		resp       string
+		respCode   int
+		wantErr    bool
+		wantResult []AuthorizedParty
+	}{
+		{
+			name:       "FunctionVersion",
+			args:       args{functionID: mockFunctionID, functionVersionID: mockVersionID},
+			target:     fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+			resp:       fmt.Sprintf(authorizeResponse, mockFunctionID),
+			respCode:   200,
+			wantResult: request.AuthorizedParties,
+		},
+		{
+			name:       "Function",
+			args:       args{functionID: mockFunctionID, allVersions: true},
+			target:     fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			resp:       fmt.Sprintf(authorizeResponse, mockFunctionID),
+			respCode:   200,
+			wantResult: request.AuthorizedParties,
+		},
+		{
+			name:    "MissingVersionID",
+			args:    args{functionID: mockFunctionID},
+			target:  fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			wantErr: true,
+		},
+		{
+			name:     "Failed",
+			args:     args{functionID: mockFunctionID, allVersions: true},
+			target:   fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			resp:     mockErrorResponse,
+			respCode: 400,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(t, tt.target, http.MethodPost, nvcfRequestHeaders, request, tt.resp, tt.respCode),
+				},
+			}
+			var got *AuthorizeAccountsToInvokeFunctionResponse
+			var err error
+			if tt.args.allVersions {
+				got, err = c.AuthorizeAccountsToInvokeAllFunctionVersions(context.Background(), tt.args.functionID, request)
+			} else {
+				got, err = c.AuthorizeAccountsToInvokeFunction(context.Background(), tt.args.functionID, tt.args.functionVersionID, request)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NVCFClient.AuthorizeAccountsToInvokeFunction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantResult, got.Function.AuthorizedParties)
+			}
+		})
+	}
+}
+
+func TestNVCFClient_UnAuthorizeAllExtraAccountsToInvokeFunction(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		functionID        string
+		functionVersionID string
+		allVersions       bool
+	}
+	tests := []struct {
+		name     string
+		args     args
+		target   string
+		resp     string
+		respCode int
+		wantErr  bool
+	}{
+		{
+			name:     "FunctionVersion",
+			args:     args{functionID: mockFunctionID, functionVersionID: mockVersionID},
+			target:   fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+			resp:     fmt.Sprintf(`{"function": {"id": "%s", "authorizedParties": []}}`, mockFunctionID),
+			respCode: 200,
+		},
+		{
+			name:     "Function",
+			args:     args{functionID: mockFunctionID, allVersions: true},
+			target:   fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			resp:     fmt.Sprintf(`{"function": {"id": "%s", "authorizedParties": []}}`, mockFunctionID),
+			respCode: 200,
+		},
+		{
+			name:    "MissingVersionID",
+			args:    args{functionID: mockFunctionID},
+			target:  fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			wantErr: true,
+		},
+		{
+			name:     "Failed",
+			args:     args{functionID: mockFunctionID, allVersions: true},
+			target:   fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/authorizations/functions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID),
+			resp:     mockErrorResponse,
+			respCode: 400,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(t, tt.target, http.MethodDelete, nvcfRequestHeaders, nil, tt.resp, tt.respCode),
+				},
+			}
+			var err error
+			if tt.args.allVersions {
+				err = c.UnAuthorizeAllExtraAccountsToInvokeAllFunctionVersions(context.Background(), tt.args.functionID)
+			} else {
+				err = c.UnAuthorizeAllExtraAccountsToInvokeFunction(context.Background(), tt.args.functionID, tt.args.functionVersionID)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NVCFClient.UnAuthorizeAllExtraAccountsToInvokeFunction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
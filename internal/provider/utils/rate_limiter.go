@@ -0,0 +1,188 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DefaultRequestsPerSecond = 10
+	DefaultBurst             = 10
+	// minRateFraction is the lowest rate, relative to the configured one, the limiter slows down to after 429s.
+	minRateFraction = 0.1
+	// epochThreshold tells a unix timestamp from a number of seconds in a rate limit reset header.
+	epochThreshold = 1_000_000_000
+)
+
+// RateLimiter is a token bucket shared by every request of an NVCFClient.
+// After a 429, it halves its rate, then gets back to the configured rate as
+// requests succeed. When a response tells when the rate limit resets, every
+// request waits until then, for at most DefaultRetryAfterMax like a retried
+// request. A nil RateLimiter does not limit.
+type RateLimiter struct {
+	mu sync.Mutex
+	// limit is the configured rate in requests per second, rate the current one.
+	limit       float64
+	rate        float64
+	burst       int
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	// maxPause caps the pause requested by a rate limit reset header.
+	maxPause time.Duration
+}
+
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		limit:    requestsPerSecond,
+		rate:     requestsPerSecond,
+		burst:    burst,
+		tokens:   float64(burst),
+		last:     time.Now(),
+		maxPause: DefaultRetryAfterMax,
+	}
+}
+
+// Wait blocks until the request may be sent or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	wait := l.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("rate limiting the request for %s", wait))
+
+	if err := sleepWithContext(ctx, wait); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token and returns how long to wait before using it.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(now)
+	l.tokens--
+
+	var wait time.Duration
+	if now.Before(l.pausedUntil) {
+		wait = l.pausedUntil.Sub(now)
+	}
+	if l.tokens < 0 {
+		wait += time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	return wait
+}
+
+// cancel gives back the token of a request which did not wait for it.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+}
+
+// refill adds the tokens accumulated since the last call, none while paused.
+func (l *RateLimiter) refill(now time.Time) {
+	from := l.last
+	if l.pausedUntil.After(from) {
+		from = l.pausedUntil
+	}
+
+	if now.After(from) {
+		l.tokens = min(float64(l.burst), l.tokens+now.Sub(from).Seconds()*l.rate)
+	}
+	if now.After(l.last) {
+		l.last = now
+	}
+}
+
+// Observe adapts the rate to the response of a request.
+func (l *RateLimiter) Observe(ctx context.Context, response *http.Response) {
+	if l == nil || response == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	throttled := response.StatusCode == http.StatusTooManyRequests
+
+	if throttled {
+		l.refill(now)
+		l.rate = max(l.rate/2, l.limit*minRateFraction)
+		l.tokens = min(l.tokens, 0)
+	} else if l.rate < l.limit {
+		l.rate = min(l.limit, l.rate+l.limit*minRateFraction)
+	}
+
+	// The remaining quota may also run out on a successful response.
+	if !throttled && response.Header.Get("X-RateLimit-Remaining") != "0" {
+		return
+	}
+
+	reset, ok := parseRateLimitReset(response.Header, now)
+	if !ok {
+		return
+	}
+	if l.maxPause > 0 && reset > l.maxPause {
+		reset = l.maxPause
+	}
+
+	if pausedUntil := now.Add(reset); pausedUntil.After(l.pausedUntil) {
+		l.refill(now)
+		l.pausedUntil = pausedUntil
+		tflog.Warn(ctx, fmt.Sprintf("NVCF API rate limit reached, pausing requests for %s", reset))
+	}
+}
+
+// parseRateLimitReset returns how long until the rate limit resets, from the
+// Retry-After, RateLimit-Reset or X-RateLimit-Reset header, the latter being
+// either a number of seconds or a unix timestamp.
+func parseRateLimitReset(header http.Header, now time.Time) (time.Duration, bool) {
+	if retryAfter, ok := parseRetryAfter(header.Get("Retry-After")); ok {
+		return retryAfter, true
+	}
+
+	for _, name := range []string{"RateLimit-Reset", "X-RateLimit-Reset"} {
+		seconds, err := strconv.ParseInt(header.Get(name), 10, 64)
+		if err != nil || seconds < 0 {
+			continue
+		}
+
+		if seconds >= epochThreshold {
+			return max(0, time.Unix(seconds, 0).Sub(now)), true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	return 0, false
+}
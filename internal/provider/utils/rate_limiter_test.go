@@ -0,0 +1,199 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_reserve(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	l := NewRateLimiter(10, 2)
+	l.last = now
+
+	// The burst is sent at once, then one request every 100ms.
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+	assert.Equal(t, 100*time.Millisecond, l.reserve(now))
+	assert.Equal(t, 200*time.Millisecond, l.reserve(now))
+
+	// Tokens accumulate up to the burst.
+	later := now.Add(time.Hour)
+	assert.Equal(t, time.Duration(0), l.reserve(later))
+	assert.Equal(t, time.Duration(0), l.reserve(later))
+	assert.Equal(t, 100*time.Millisecond, l.reserve(later))
+}
+
+func TestRateLimiter_Observe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		headers    map[string]string
+		wantRate   float64
+		wantPaused time.Duration
+	}{
+		{
+			name:       "Success",
+			statusCode: http.StatusOK,
+			wantRate:   10,
+		},
+		{
+			name:       "TooManyRequests",
+			statusCode: http.StatusTooManyRequests,
+			wantRate:   5,
+		},
+		{
+			name:       "RetryAfter",
+			statusCode: http.StatusTooManyRequests,
+			headers:    map[string]string{"Retry-After": "30"},
+			wantRate:   5,
+			wantPaused: 30 * time.Second,
+		},
+		{
+			name:       "RateLimitReset",
+			statusCode: http.StatusTooManyRequests,
+			headers:    map[string]string{"RateLimit-Reset": "20"},
+			wantRate:   5,
+			wantPaused: 20 * time.Second,
+		},
+		{
+			name:       "XRateLimitResetTimestamp",
+			statusCode: http.StatusTooManyRequests,
+			headers:    map[string]string{"X-RateLimit-Reset": strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)},
+			wantRate:   5,
+			wantPaused: time.Minute,
+		},
+		{
+			name:       "RetryAfterCapped",
+			statusCode: http.StatusTooManyRequests,
+			headers:    map[string]string{"Retry-After": "86400"},
+			wantRate:   5,
+			wantPaused: DefaultRetryAfterMax,
+		},
+		{
+			name:       "RateLimitResetCapped",
+			statusCode: http.StatusTooManyRequests,
+			headers:    map[string]string{"X-RateLimit-Reset": strconv.FormatInt(time.Now().Add(24*time.Hour).Unix(), 10)},
+			wantRate:   5,
+			wantPaused: DefaultRetryAfterMax,
+		},
+		{
+			name:       "RemainingQuotaExhausted",
+			statusCode: http.StatusOK,
+			headers:    map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "10"},
+			wantRate:   10,
+			wantPaused: 10 * time.Second,
+		},
+		{
+			name:       "RemainingQuota",
+			statusCode: http.StatusOK,
+			headers:    map[string]string{"X-RateLimit-Remaining": "5", "X-RateLimit-Reset": "10"},
+			wantRate:   10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := NewRateLimiter(10, 10)
+			response := &http.Response{StatusCode: tt.statusCode, Header: http.Header{}}
+			for k, v := range tt.headers {
+				response.Header.Set(k, v)
+			}
+
+			l.Observe(context.Background(), response)
+
+			assert.Equal(t, tt.wantRate, l.rate)
+			if tt.wantPaused == 0 {
+				assert.True(t, l.pausedUntil.IsZero())
+			} else {
+				assert.WithinDuration(t, time.Now().Add(tt.wantPaused), l.pausedUntil, 2*time.Second)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_ObserveRecovers(t *testing.T) {
+	t.Parallel()
+
+	l := NewRateLimiter(10, 10)
+	throttled := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	succeeded := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+	for i := 0; i < 10; i++ {
+		l.Observe(context.Background(), throttled)
+	}
+	assert.Equal(t, 1.0, l.rate, "the rate does not go below a tenth of the configured rate")
+
+	for i := 0; i < 20; i++ {
+		l.Observe(context.Background(), succeeded)
+	}
+	assert.Equal(t, 10.0, l.rate, "the rate does not go above the configured rate")
+}
+
+func TestRateLimiter_WaitContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	l := NewRateLimiter(0.001, 1)
+	assert.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+	assert.InDelta(t, 0, l.tokens, 0.01, "the canceled request gives its token back")
+}
+
+func TestNVCFClient_sendRequestRateLimited(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	server := newSequenceServer(t, []int{http.StatusTooManyRequests, http.StatusOK}, map[string]string{"Retry-After": "0"}, &attempts)
+
+	c := &NVCFClient{
+		NgcEndpoint: server.URL,
+		NgcOrg:      mockOrg,
+		HttpClient:  server.Client(),
+		RetryPolicy: testRetryPolicy(3),
+		RateLimiter: NewRateLimiter(100, 5),
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// 10 requests and the retry of the throttled one, 5 of them above the burst.
+	assert.Equal(t, int32(11), atomic.LoadInt32(&attempts))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
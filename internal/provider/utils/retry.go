@@ -0,0 +1,175 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 4
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+	DefaultRetryJitter  = 0.2
+	// DefaultRetryAfterMax bounds the wait a server may request with Retry-After.
+	DefaultRetryAfterMax = 5 * time.Minute
+)
+
+// RetryPolicy controls how NVCFClient retries requests that failed with a
+// transient error. The zero value disables retries.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// RetryWaitMin is the backoff before the first retry, doubled on every following retry.
+	RetryWaitMin time.Duration
+	// RetryWaitMax caps the computed backoff. It does not cap a server provided Retry-After.
+	RetryWaitMax time.Duration
+	// RetryAfterMax caps a server provided Retry-After, zero leaves it uncapped.
+	RetryAfterMax time.Duration
+	// Jitter is the fraction of the backoff, between 0 and 1, which is randomized.
+	Jitter float64
+	// RetryableStatusCodes are the HTTP status codes considered transient.
+	RetryableStatusCodes map[int]bool
+}
+
+func DefaultRetryableStatusCodes() map[int]bool {
+	return map[int]bool{
+		http.StatusTooManyRequests:    true,
+		http.StatusBadGateway:         true,
+		http.StatusServiceUnavailable: true,
+		http.StatusGatewayTimeout:     true,
+	}
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:           DefaultMaxRetries,
+		RetryWaitMin:         DefaultRetryWaitMin,
+		RetryWaitMax:         DefaultRetryWaitMax,
+		RetryAfterMax:        DefaultRetryAfterMax,
+		Jitter:               DefaultRetryJitter,
+		RetryableStatusCodes: DefaultRetryableStatusCodes(),
+	}
+}
+
+// isRetryableStatusCode reports whether the status code is transient under the policy.
+func (p RetryPolicy) isRetryableStatusCode(statusCode int) bool {
+	return p.RetryableStatusCodes[statusCode]
+}
+
+// isIdempotentMethod reports whether sending the request twice has the same effect as sending it once.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRequestNotSent reports whether the error happened before the request reached the server,
+// such as a DNS or a dial failure.
+func isRequestNotSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// shouldRetry decides whether the attempt, numbered from zero, should be retried.
+// A request of a non-idempotent method, such as the POST creating a function version, may have been
+// processed by the server when its response is lost, so it is only retried on a 429 or when it was not sent.
+func (p RetryPolicy) shouldRetry(ctx context.Context, attempt int, method string, response *http.Response, err error) bool {
+	if attempt >= p.MaxRetries || ctx.Err() != nil {
+		return false
+	}
+
+	// Connection level failures, such as a connection reset, carry no response.
+	if err != nil {
+		return isIdempotentMethod(method) || isRequestNotSent(err)
+	}
+
+	if !isIdempotentMethod(method) {
+		return response.StatusCode == http.StatusTooManyRequests && p.isRetryableStatusCode(response.StatusCode)
+	}
+
+	return p.isRetryableStatusCode(response.StatusCode)
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header on the response, capped by RetryAfterMax, takes precedence over the exponential backoff.
+func (p RetryPolicy) backoff(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			if p.RetryAfterMax > 0 && retryAfter > p.RetryAfterMax {
+				return p.RetryAfterMax
+			}
+			return retryAfter
+		}
+	}
+
+	wait := float64(p.RetryWaitMin) * math.Pow(2, float64(attempt))
+	if p.RetryWaitMax > 0 && wait > float64(p.RetryWaitMax) {
+		wait = float64(p.RetryWaitMax)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		wait -= wait * jitter * rand.Float64() //nolint:gosec
+	}
+
+	return time.Duration(wait)
+}
+
+// parseRetryAfter accepts both the delay-seconds and the HTTP-date form of Retry-After.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
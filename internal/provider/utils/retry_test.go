@@ -0,0 +1,333 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy(maxRetries int) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:           maxRetries,
+		RetryWaitMin:         time.Millisecond,
+		RetryWaitMax:         5 * time.Millisecond,
+		Jitter:               DefaultRetryJitter,
+		RetryableStatusCodes: DefaultRetryableStatusCodes(),
+	}
+}
+
+// newSequenceServer replies with the given status codes in order, repeating the last one.
+func newSequenceServer(t *testing.T, statusCodes []int, headers map[string]string, attempts *int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := int(atomic.AddInt32(attempts, 1)) - 1
+		if attempt >= len(statusCodes) {
+			attempt = len(statusCodes) - 1
+		}
+
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(statusCodes[attempt])
+
+		if statusCodes[attempt] == http.StatusOK {
+			fmt.Fprintf(w, `{"function": %s}`, mockContainerBasedFunctionInfo)
+		} else {
+			fmt.Fprint(w, mockErrorResponse)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNVCFClient_sendRequestRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		statusCodes  []int
+		headers      map[string]string
+		retryPolicy  RetryPolicy
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "RetryUntilSucceed",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			retryPolicy:  testRetryPolicy(3),
+			wantAttempts: 3,
+			wantErr:      false,
+		},
+		{
+			name:         "RetryTooManyRequestsWithRetryAfter",
+			statusCodes:  []int{http.StatusTooManyRequests, http.StatusOK},
+			headers:      map[string]string{"Retry-After": "0"},
+			retryPolicy:  testRetryPolicy(1),
+			wantAttempts: 2,
+			wantErr:      false,
+		},
+		{
+			name:         "RetryExhausted",
+			statusCodes:  []int{http.StatusServiceUnavailable},
+			retryPolicy:  testRetryPolicy(2),
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "NotRetryNonRetryableStatusCode",
+			statusCodes:  []int{http.StatusBadRequest},
+			retryPolicy:  testRetryPolicy(3),
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "NotRetryWithZeroPolicy",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusOK},
+			retryPolicy:  RetryPolicy{},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := newSequenceServer(t, tt.statusCodes, tt.headers, &attempts)
+
+			c := &NVCFClient{
+				NgcEndpoint: server.URL,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient:  server.Client(),
+				RetryPolicy: tt.retryPolicy,
+			}
+			_, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NVCFClient.GetNvidiaCloudFunctionVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func TestNVCFClient_sendRequestRetryConnectionReset(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("failed to hijack connection: %s", err.Error())
+				return
+			}
+			conn.Close()
+			return
+		}
+		fmt.Fprintf(w, `{"function": %s}`, mockContainerBasedFunctionInfo)
+	}))
+	t.Cleanup(server.Close)
+
+	c := &NVCFClient{
+		NgcEndpoint: server.URL,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient:  server.Client(),
+		RetryPolicy: testRetryPolicy(2),
+	}
+
+	resp, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+	assert.NoError(t, err)
+	assert.Equal(t, mockFunctionID, resp.Function.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestNVCFClient_sendRequestRetryNonIdempotent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		statusCodes  []int
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "RetryTooManyRequests",
+			statusCodes:  []int{http.StatusTooManyRequests, http.StatusOK},
+			wantAttempts: 2,
+			wantErr:      false,
+		},
+		{
+			name:         "NotRetryServiceUnavailable",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "NotRetryGatewayTimeout",
+			statusCodes:  []int{http.StatusGatewayTimeout, http.StatusOK},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := newSequenceServer(t, tt.statusCodes, nil, &attempts)
+
+			c := &NVCFClient{
+				NgcEndpoint: server.URL,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				HttpClient:  server.Client(),
+				RetryPolicy: testRetryPolicy(3),
+			}
+			_, err := c.CreateNvidiaCloudFunction(context.Background(), mockFunctionID, CreateNvidiaCloudFunctionRequest{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NVCFClient.CreateNvidiaCloudFunction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func TestNVCFClient_sendRequestNotRetryNonIdempotentConnectionReset(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("failed to hijack connection: %s", err.Error())
+			return
+		}
+		conn.Close()
+	}))
+	t.Cleanup(server.Close)
+
+	c := &NVCFClient{
+		NgcEndpoint: server.URL,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient:  server.Client(),
+		RetryPolicy: testRetryPolicy(2),
+	}
+
+	// The server may have created the version before the connection was reset.
+	_, err := c.CreateNvidiaCloudFunction(context.Background(), mockFunctionID, CreateNvidiaCloudFunctionRequest{})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryPolicy_shouldRetry(t *testing.T) {
+	t.Parallel()
+
+	policy := testRetryPolicy(3)
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+	assert.True(t, policy.shouldRetry(context.Background(), 0, http.MethodGet, nil, readErr))
+	assert.True(t, policy.shouldRetry(context.Background(), 0, http.MethodDelete, nil, readErr))
+	assert.True(t, policy.shouldRetry(context.Background(), 0, http.MethodPost, nil, dialErr))
+	assert.True(t, policy.shouldRetry(context.Background(), 0, http.MethodPost, nil, &net.DNSError{Err: "no such host"}))
+	assert.False(t, policy.shouldRetry(context.Background(), 0, http.MethodPost, nil, readErr))
+	assert.False(t, policy.shouldRetry(context.Background(), 3, http.MethodGet, nil, readErr))
+}
+
+func TestNVCFClient_sendRequestRetryContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	server := newSequenceServer(t, []int{http.StatusServiceUnavailable}, map[string]string{"Retry-After": "3600"}, &attempts)
+
+	c := &NVCFClient{
+		NgcEndpoint: server.URL,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient:  server.Client(),
+		RetryPolicy: testRetryPolicy(5),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetNvidiaCloudFunctionVersion(ctx, mockFunctionID, mockVersionID)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{
+		MaxRetries:   10,
+		RetryWaitMin: time.Second,
+		RetryWaitMax: 10 * time.Second,
+	}
+
+	assert.Equal(t, time.Second, policy.backoff(0, nil))
+	assert.Equal(t, 4*time.Second, policy.backoff(2, nil))
+	assert.Equal(t, 10*time.Second, policy.backoff(8, nil))
+
+	retryAfterResponse := &http.Response{Header: http.Header{}}
+	retryAfterResponse.Header.Set("Retry-After", "42")
+	assert.Equal(t, 42*time.Second, policy.backoff(0, retryAfterResponse))
+
+	policy.RetryAfterMax = 30 * time.Second
+	assert.Equal(t, 30*time.Second, policy.backoff(0, retryAfterResponse))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		wait := policy.backoff(1, nil)
+		assert.GreaterOrEqual(t, wait, time.Second)
+		assert.LessOrEqual(t, wait, 2*time.Second)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "Empty", value: "", want: 0, wantOK: false},
+		{name: "Seconds", value: "7", want: 7 * time.Second, wantOK: true},
+		{name: "Negative", value: "-1", want: 0, wantOK: false},
+		{name: "PastDate", value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0, wantOK: true},
+		{name: "Invalid", value: "soon", want: 0, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,279 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTokenExpiryWindow is how long before its expiry a token is renewed.
+const DefaultTokenExpiryWindow = time.Minute
+
+// TokenSource provides the credential sent as the Bearer token of NGC API requests.
+// Implementations must be safe for concurrent use.
+type TokenSource interface {
+	// Token returns a valid token, renewing it when needed.
+	Token(ctx context.Context) (string, error)
+	// Invalidate drops the cached token, typically after a 401, so that the next Token call renews it.
+	Invalidate()
+}
+
+// StaticTokenSource always returns the same token.
+type StaticTokenSource string
+
+func (s StaticTokenSource) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+func (s StaticTokenSource) Invalidate() {}
+
+// cachedToken is embedded by the token sources renewing their token.
+type cachedToken struct {
+	mu           sync.Mutex
+	token        string
+	expiresAt    time.Time
+	expiryWindow time.Duration
+}
+
+// valid must be called with mu held.
+func (c *cachedToken) valid() bool {
+	if c.token == "" {
+		return false
+	}
+	if c.expiresAt.IsZero() {
+		return true
+	}
+	return time.Until(c.expiresAt) > c.expiryWindow
+}
+
+func (c *cachedToken) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = ""
+	c.expiresAt = time.Time{}
+}
+
+// FileTokenSource reads the API key from a file, which is read again after an invalidation.
+type FileTokenSource struct {
+	Path string
+
+	cachedToken
+}
+
+func NewFileTokenSource(path string) *FileTokenSource {
+	return &FileTokenSource{Path: path}
+}
+
+func (s *FileTokenSource) Token(context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.valid() {
+		return s.token, nil
+	}
+
+	content, err := os.ReadFile(s.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("API key file %s is empty", s.Path)
+	}
+
+	s.token = token
+	return s.token, nil
+}
+
+// CommandTokenSource runs an external command printing the API key on its
+// standard output, either as is or as a JSON object such as
+// {"api_key": "nvapi-...", "expires_at": "2024-06-01T12:00:00Z"}.
+// The command runs again when the key nears its expiry or after an invalidation.
+type CommandTokenSource struct {
+	Command string
+
+	cachedToken
+}
+
+type commandTokenOutput struct {
+	ApiKey    string    `json:"api_key"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewCommandTokenSource(command string) *CommandTokenSource {
+	return &CommandTokenSource{
+		Command:     command,
+		cachedToken: cachedToken{expiryWindow: DefaultTokenExpiryWindow},
+	}
+}
+
+func (s *CommandTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.valid() {
+		return s.token, nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", s.Command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("API key command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	token, expiresAt, err := parseCommandTokenOutput(stdout.Bytes())
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expiresAt = expiresAt
+	return s.token, nil
+}
+
+func parseCommandTokenOutput(output []byte) (string, time.Time, error) {
+	output = bytes.TrimSpace(output)
+
+	if !bytes.HasPrefix(output, []byte("{")) {
+		if len(output) == 0 {
+			return "", time.Time{}, errors.New("API key command printed nothing")
+		}
+		return string(output), time.Time{}, nil
+	}
+
+	var parsed commandTokenOutput
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to parse API key command JSON output: %w", err)
+	}
+	if parsed.ApiKey == "" {
+		return "", time.Time{}, errors.New("API key command JSON output has no \"api_key\"")
+	}
+
+	return parsed.ApiKey, parsed.ExpiresAt, nil
+}
+
+// ClientCredentialsTokenSource exchanges a service account client ID and secret
+// for short-lived access tokens with the OAuth2 client credentials grant, and
+// renews them before their expiry.
+type ClientCredentialsTokenSource struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	HttpClient   *http.Client
+
+	cachedToken
+}
+
+type clientCredentialsTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type clientCredentialsErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func NewClientCredentialsTokenSource(httpClient *http.Client, tokenURL string, clientID string, clientSecret string, scopes []string) *ClientCredentialsTokenSource {
+	return &ClientCredentialsTokenSource{
+		TokenURL:     tokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		HttpClient:   httpClient,
+	}
+}
+
+func (s *ClientCredentialsTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.valid() {
+		return s.token, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.Scopes, " "))
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("invalid token URL: %w", err)
+	}
+	request.SetBasicAuth(url.QueryEscape(s.ClientID), url.QueryEscape(s.ClientSecret))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := s.HttpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to request an access token: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the access token response: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		var errorResponse clientCredentialsErrorResponse
+		if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Error != "" {
+			return "", fmt.Errorf("token endpoint returned %s: %s %s", response.Status, errorResponse.Error, errorResponse.ErrorDescription)
+		}
+		return "", fmt.Errorf("token endpoint returned %s", response.Status)
+	}
+
+	var tokenResponse clientCredentialsTokenResponse
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return "", fmt.Errorf("failed to parse the access token response: %w", err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return "", errors.New("token endpoint returned no access_token")
+	}
+
+	s.token = tokenResponse.AccessToken
+	s.expiresAt = time.Time{}
+	if tokenResponse.ExpiresIn > 0 {
+		lifetime := time.Duration(tokenResponse.ExpiresIn) * time.Second
+		s.expiresAt = time.Now().Add(lifetime)
+		// Short-lived tokens are renewed at half of their lifetime.
+		s.expiryWindow = min(DefaultTokenExpiryWindow, lifetime/2)
+	}
+
+	return s.token, nil
+}
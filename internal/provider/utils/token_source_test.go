@@ -0,0 +1,386 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingCommand returns a shell command printing the output and counting its runs in a file.
+func countingCommand(t *testing.T, output string) (string, func() int) {
+	t.Helper()
+
+	counter := filepath.Join(t.TempDir(), "counter")
+	command := fmt.Sprintf("echo run >> '%s'; printf '%%s' '%s'", counter, output)
+
+	return command, func() int {
+		content, _ := os.ReadFile(counter)
+		return strings.Count(string(content), "run")
+	}
+}
+
+func TestCommandTokenSource_Token(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		output    string
+		wantToken string
+		wantRuns  int
+		wantErr   string
+	}{
+		{
+			name:      "PlainKey",
+			output:    "nvapi-mock\n",
+			wantToken: "nvapi-mock",
+			wantRuns:  1,
+		},
+		{
+			name:      "JSONWithoutExpiry",
+			output:    `{"api_key": "nvapi-mock"}`,
+			wantToken: "nvapi-mock",
+			wantRuns:  1,
+		},
+		{
+			name:      "JSONWithFarExpiry",
+			output:    fmt.Sprintf(`{"api_key": "nvapi-mock", "expires_at": "%s"}`, time.Now().Add(time.Hour).Format(time.RFC3339)),
+			wantToken: "nvapi-mock",
+			wantRuns:  1,
+		},
+		{
+			name:      "JSONNearExpiry",
+			output:    fmt.Sprintf(`{"api_key": "nvapi-mock", "expires_at": "%s"}`, time.Now().Add(30*time.Second).Format(time.RFC3339)),
+			wantToken: "nvapi-mock",
+			wantRuns:  3,
+		},
+		{
+			name:    "JSONWithoutKey",
+			output:  `{"token": "nvapi-mock"}`,
+			wantErr: `API key command JSON output has no "api_key"`,
+		},
+		{
+			name:    "EmptyOutput",
+			output:  "",
+			wantErr: "API key command printed nothing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, runs := countingCommand(t, tt.output)
+			s := NewCommandTokenSource(command)
+
+			for i := 0; i < 3; i++ {
+				token, err := s.Token(context.Background())
+				if tt.wantErr != "" {
+					assert.EqualError(t, err, tt.wantErr)
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantToken, token)
+			}
+			assert.Equal(t, tt.wantRuns, runs())
+		})
+	}
+}
+
+func TestCommandTokenSource_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	command, runs := countingCommand(t, "nvapi-mock")
+	s := NewCommandTokenSource(command)
+
+	_, _ = s.Token(context.Background())
+	_, _ = s.Token(context.Background())
+	s.Invalidate()
+	_, _ = s.Token(context.Background())
+
+	assert.Equal(t, 2, runs())
+}
+
+func TestCommandTokenSource_Failure(t *testing.T) {
+	t.Parallel()
+
+	s := NewCommandTokenSource("echo 'vault is sealed' >&2; exit 3")
+
+	_, err := s.Token(context.Background())
+	assert.ErrorContains(t, err, "exit status 3: vault is sealed")
+}
+
+func TestFileTokenSource_Token(t *testing.T) {
+	t.Parallel()
+
+	keyFile := writeTempFile(t, "api-key", []byte("nvapi-old\n"))
+	s := NewFileTokenSource(keyFile)
+
+	token, err := s.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "nvapi-old", token)
+
+	// The rotated key is only read after an invalidation.
+	assert.NoError(t, os.WriteFile(keyFile, []byte("nvapi-new"), 0o600))
+	token, _ = s.Token(context.Background())
+	assert.Equal(t, "nvapi-old", token)
+
+	s.Invalidate()
+	token, _ = s.Token(context.Background())
+	assert.Equal(t, "nvapi-new", token)
+
+	_, err = NewFileTokenSource(writeTempFile(t, "empty", nil)).Token(context.Background())
+	assert.ErrorContains(t, err, "is empty")
+
+	_, err = NewFileTokenSource(filepath.Join(t.TempDir(), "missing")).Token(context.Background())
+	assert.ErrorContains(t, err, "failed to read API key file")
+}
+
+// rotatingTokenSource returns the next token after each invalidation.
+type rotatingTokenSource struct {
+	mu          sync.Mutex
+	tokens      []string
+	invalidated int
+}
+
+func (s *rotatingTokenSource) Token(context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tokens[min(s.invalidated, len(s.tokens)-1)], nil
+}
+
+func (s *rotatingTokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.invalidated++
+}
+
+func TestNVCFClient_sendRequestRenewsAPIKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		tokens          []string
+		wantAttempts    int32
+		wantInvalidated int
+		wantErr         bool
+	}{
+		{
+			name:            "RenewedAfter401",
+			tokens:          []string{"expired-key", "valid-key"},
+			wantAttempts:    2,
+			wantInvalidated: 1,
+		},
+		{
+			name:            "RenewedOnlyOnce",
+			tokens:          []string{"expired-key", "revoked-key", "valid-key"},
+			wantAttempts:    2,
+			wantInvalidated: 1,
+			wantErr:         true,
+		},
+		{
+			name:         "ValidKey",
+			tokens:       []string{"valid-key"},
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				if r.Header.Get("Authorization") != "Bearer valid-key" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				fmt.Fprintf(w, `{"function": %s}`, mockContainerBasedFunctionInfo)
+			}))
+			defer server.Close()
+
+			tokenSource := &rotatingTokenSource{tokens: tt.tokens}
+			c := &NVCFClient{
+				NgcEndpoint: server.URL,
+				NgcOrg:      mockOrg,
+				HttpClient:  server.Client(),
+				RetryPolicy: testRetryPolicy(3),
+				TokenSource: tokenSource,
+			}
+
+			_, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NVCFClient.GetNvidiaCloudFunctionVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				assert.True(t, IsUnauthorized(err))
+			}
+			assert.Equal(t, tt.wantAttempts, atomic.LoadInt32(&attempts))
+			assert.Equal(t, tt.wantInvalidated, tokenSource.invalidated)
+		})
+	}
+}
+
+// newFakeTokenEndpoint serves the client credentials grant for the mock-client-id client,
+// issuing "access-token-<n>" tokens valid for expiresIn seconds.
+func newFakeTokenEndpoint(t *testing.T, expiresIn int) (*httptest.Server, func() int32) {
+	var issued int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if r.Method != http.MethodPost || !ok || clientID != "mock-client-id" || clientSecret != "mock-client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "invalid_client", "error_description": "client authentication failed"}`)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "unsupported_grant_type"}`)
+			return
+		}
+		n := atomic.AddInt32(&issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "access-token-%d", "token_type": "bearer", "expires_in": %d, "scope": %q}`, n, expiresIn, r.PostForm.Get("scope"))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() int32 { return atomic.LoadInt32(&issued) }
+}
+
+func TestClientCredentialsTokenSource_Token(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		expiresIn  int
+		calls      int
+		wantToken  string
+		wantIssued int32
+	}{
+		{
+			name:       "Cached",
+			expiresIn:  3600,
+			calls:      3,
+			wantToken:  "access-token-1",
+			wantIssued: 1,
+		},
+		{
+			name:       "NoExpiry",
+			expiresIn:  0,
+			calls:      2,
+			wantToken:  "access-token-1",
+			wantIssued: 1,
+		},
+		{
+			name:       "ShortLived",
+			expiresIn:  1,
+			calls:      2,
+			wantToken:  "access-token-1",
+			wantIssued: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, issued := newFakeTokenEndpoint(t, tt.expiresIn)
+			tokenSource := NewClientCredentialsTokenSource(server.Client(), server.URL, "mock-client-id", "mock-client-secret", []string{"invoke_function", "list_functions"})
+
+			var token string
+			for i := 0; i < tt.calls; i++ {
+				var err error
+				token, err = tokenSource.Token(context.Background())
+				if err != nil {
+					t.Fatalf("ClientCredentialsTokenSource.Token() error = %v", err)
+				}
+			}
+			assert.Equal(t, tt.wantToken, token)
+			assert.Equal(t, tt.wantIssued, issued())
+		})
+	}
+}
+
+func TestClientCredentialsTokenSource_Renewal(t *testing.T) {
+	t.Parallel()
+
+	server, issued := newFakeTokenEndpoint(t, 3600)
+	tokenSource := NewClientCredentialsTokenSource(server.Client(), server.URL, "mock-client-id", "mock-client-secret", nil)
+
+	token, err := tokenSource.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "access-token-1", token)
+
+	// Tokens are renewed once inside the expiry window.
+	tokenSource.expiresAt = time.Now().Add(DefaultTokenExpiryWindow / 2)
+	token, err = tokenSource.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "access-token-2", token)
+
+	tokenSource.Invalidate()
+	token, err = tokenSource.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "access-token-3", token)
+	assert.Equal(t, int32(3), issued())
+}
+
+func TestClientCredentialsTokenSource_Failure(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newFakeTokenEndpoint(t, 3600)
+
+	_, err := NewClientCredentialsTokenSource(server.Client(), server.URL, "mock-client-id", "wrong-secret", nil).Token(context.Background())
+	assert.ErrorContains(t, err, "invalid_client client authentication failed")
+
+	_, err = NewClientCredentialsTokenSource(server.Client(), server.URL+"/\x00", "mock-client-id", "mock-client-secret", nil).Token(context.Background())
+	assert.ErrorContains(t, err, "invalid token URL")
+}
+
+func TestNVCFClient_sendRequestWithClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	tokenServer, issued := newFakeTokenEndpoint(t, 3600)
+
+	var rejected int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first access token is revoked.
+		if r.Header.Get("Authorization") != "Bearer access-token-2" {
+			atomic.AddInt32(&rejected, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"function": %s}`, mockContainerBasedFunctionInfo)
+	}))
+	defer server.Close()
+
+	c := &NVCFClient{
+		NgcEndpoint: server.URL,
+		NgcOrg:      mockOrg,
+		HttpClient:  server.Client(),
+		TokenSource: NewClientCredentialsTokenSource(tokenServer.Client(), tokenServer.URL, "mock-client-id", "mock-client-secret", nil),
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&rejected))
+	assert.Equal(t, int32(2), issued())
+}
@@ -0,0 +1,219 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	CorrelationIDHeader = "X-Request-Id"
+	redactedValue       = "[REDACTED]"
+)
+
+// DefaultRedactedJSONPaths are always redacted from the logged request and response bodies.
+var DefaultRedactedJSONPaths = []string{
+	"secrets[].value",
+	"function.secrets[].value",
+	"access_token",
+	"refresh_token",
+	"id_token",
+}
+
+var redactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+func UserAgent(providerVersion string) string {
+	return "terraform-provider-ngc/" + providerVersion
+}
+
+// Transport is the http.RoundTripper shared by every NGC API client. It sets the
+// User-Agent, tags each request with a correlation ID and logs the redacted
+// requests and responses at debug level.
+type Transport struct {
+	Base      http.RoundTripper
+	UserAgent string
+	// RedactedJSONPaths lists the body fields replaced before logging. Keys are
+	// separated by dots and a "[]" suffix walks every element of an array,
+	// such as "secrets[].value".
+	RedactedJSONPaths []string
+}
+
+func NewTransport(base http.RoundTripper, providerVersion string, redactedJSONPaths []string) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{
+		Base:              base,
+		UserAgent:         UserAgent(providerVersion),
+		RedactedJSONPaths: append(append([]string{}, DefaultRedactedJSONPaths...), redactedJSONPaths...),
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	req.Header.Set("User-Agent", t.UserAgent)
+
+	correlationID := req.Header.Get(CorrelationIDHeader)
+	if correlationID == "" {
+		correlationID = uuid.NewString()
+		req.Header.Set(CorrelationIDHeader, correlationID)
+	}
+
+	ctx := tflog.SetField(req.Context(), "correlation_id", correlationID)
+	ctx = tflog.SetField(ctx, "http_method", req.Method)
+	ctx = tflog.SetField(ctx, "http_url", req.URL.String())
+
+	requestBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Sending NGC API request", map[string]interface{}{
+		"request_header": redactHeaders(req.Header),
+		"request_body":   redactJSON(requestBody, t.RedactedJSONPaths),
+	})
+
+	startedAt := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+	duration := time.Since(startedAt)
+
+	if err != nil {
+		tflog.Debug(ctx, "NGC API request failed", map[string]interface{}{
+			"error":    err.Error(),
+			"duration": duration.String(),
+		})
+		return resp, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	tflog.Debug(ctx, "Received NGC API response", map[string]interface{}{
+		"response_status": resp.Status,
+		"response_header": redactHeaders(resp.Header),
+		"response_body":   redactJSON(responseBody, t.RedactedJSONPaths),
+		"duration":        duration.String(),
+	})
+
+	return resp, nil
+}
+
+// peekRequestBody reads the request body and leaves an unread copy on the request.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return body, nil
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+
+	for k, v := range header {
+		redacted[k] = strings.Join(v, ", ")
+	}
+
+	for _, k := range redactedHeaders {
+		if _, ok := redacted[http.CanonicalHeaderKey(k)]; ok {
+			redacted[http.CanonicalHeaderKey(k)] = redactedValue
+		}
+	}
+
+	return redacted
+}
+
+// redactJSON replaces the values at the given paths. A body which is not JSON is returned as is.
+func redactJSON(body []byte, paths []string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return string(body)
+	}
+
+	for _, p := range paths {
+		redactJSONPath(document, strings.Split(p, "."))
+	}
+
+	redacted, err := json.Marshal(document)
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+func redactJSONPath(node interface{}, segments []string) {
+	object, ok := node.(map[string]interface{})
+	if !ok || len(segments) == 0 {
+		return
+	}
+
+	key, isArray := strings.CutSuffix(segments[0], "[]")
+	value, ok := object[key]
+	if !ok || value == nil {
+		return
+	}
+
+	if !isArray {
+		if len(segments) == 1 {
+			object[key] = redactedValue
+			return
+		}
+		redactJSONPath(value, segments[1:])
+		return
+	}
+
+	elements, ok := value.([]interface{})
+	if !ok {
+		return
+	}
+
+	for i, element := range elements {
+		if len(segments) == 1 {
+			elements[i] = redactedValue
+			continue
+		}
+		redactJSONPath(element, segments[1:])
+	}
+}
@@ -0,0 +1,146 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var gotHeader http.Header
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Clone()
+		gotBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=mock-session")
+		fmt.Fprint(w, `{"function": {"secrets": [{"name": "token", "value": "mock-response-secret"}], "containerEnvironment": [{"key": "A", "value": "mock-env-value"}]}}`)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+
+	client := &http.Client{Transport: NewTransport(server.Client().Transport, "1.2.3", []string{"function.containerEnvironment[].value"})}
+
+	requestBody := `{"name": "mock", "secrets": [{"name": "token", "value": "mock-request-secret"}]}`
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, bytes.NewBufferString(requestBody))
+	req.Header.Set("Authorization", "Bearer mock-api-key")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Transport.RoundTrip() error = %v", err)
+	}
+	responseBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	// The request and response go through untouched.
+	assert.Equal(t, "terraform-provider-ngc/1.2.3", gotHeader.Get("User-Agent"))
+	assert.NotEmpty(t, gotHeader.Get(CorrelationIDHeader))
+	assert.Equal(t, "Bearer mock-api-key", gotHeader.Get("Authorization"))
+	assert.Equal(t, requestBody, string(gotBody))
+	assert.Contains(t, string(responseBody), "mock-response-secret")
+	assert.Empty(t, req.Header.Get(CorrelationIDHeader), "the caller request must not be modified")
+
+	// The logs are redacted and carry the correlation ID.
+	assert.Contains(t, logs.String(), gotHeader.Get(CorrelationIDHeader))
+	for _, secret := range []string{"mock-api-key", "mock-request-secret", "mock-response-secret", "mock-env-value", "mock-session"} {
+		assert.NotContains(t, logs.String(), secret)
+	}
+}
+
+func TestTransport_RoundTripKeepsCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	var gotCorrelationID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCorrelationID = r.Header.Get(CorrelationIDHeader)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(server.Client().Transport, "dev", nil)}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+	req.Header.Set(CorrelationIDHeader, "mock-correlation-id")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Transport.RoundTrip() error = %v", err)
+	}
+	resp.Body.Close()
+
+	assert.Equal(t, "mock-correlation-id", gotCorrelationID)
+}
+
+func TestRedactJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		body  string
+		paths []string
+		want  string
+	}{
+		{
+			name:  "ArrayElementField",
+			body:  `{"secrets": [{"name": "a", "value": "x"}, {"name": "b", "value": "y"}]}`,
+			paths: DefaultRedactedJSONPaths,
+			want:  `{"secrets":[{"name":"a","value":"[REDACTED]"},{"name":"b","value":"[REDACTED]"}]}`,
+		},
+		{
+			name:  "NestedField",
+			body:  `{"deployment": {"configuration": {"password": "x"}, "backend": "GFN"}}`,
+			paths: []string{"deployment.configuration"},
+			want:  `{"deployment":{"backend":"GFN","configuration":"[REDACTED]"}}`,
+		},
+		{
+			name:  "WholeArray",
+			body:  `{"tags": ["a", "b"]}`,
+			paths: []string{"tags[]"},
+			want:  `{"tags":["[REDACTED]","[REDACTED]"]}`,
+		},
+		{
+			name:  "MissingPath",
+			body:  `{"name": "mock"}`,
+			paths: []string{"secrets[].value", "function.secrets[].value"},
+			want:  `{"name":"mock"}`,
+		},
+		{
+			name:  "NotJSON",
+			body:  "<html>oops</html>",
+			paths: DefaultRedactedJSONPaths,
+			want:  "<html>oops</html>",
+		},
+		{
+			name:  "Empty",
+			paths: DefaultRedactedJSONPaths,
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactJSON([]byte(tt.body), tt.paths))
+		})
+	}
+}
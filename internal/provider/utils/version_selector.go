@@ -0,0 +1,66 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	VersionSelectorLatest       = "latest"
+	VersionSelectorLatestActive = "latest_active"
+	VersionSelectorOldest       = "oldest"
+)
+
+func VersionSelectors() []string {
+	return []string{VersionSelectorLatest, VersionSelectorLatestActive, VersionSelectorOldest}
+}
+
+// SelectNvidiaCloudFunctionVersion picks one of the function versions according to the selector,
+// ordering versions by CreatedAt. Returns nil when no version matches.
+func SelectNvidiaCloudFunctionVersion(versions []NvidiaCloudFunctionInfo, selector string) (*NvidiaCloudFunctionInfo, error) {
+	candidates := make([]NvidiaCloudFunctionInfo, 0, len(versions))
+
+	switch selector {
+	case VersionSelectorLatest, VersionSelectorOldest:
+		candidates = append(candidates, versions...)
+	case VersionSelectorLatestActive:
+		for _, v := range versions {
+			if v.Status == "ACTIVE" {
+				candidates = append(candidates, v)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported version selector %q, expected one of %q", selector, VersionSelectors())
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	candidates = SortedFunctionVersions(candidates)
+
+	if selector == VersionSelectorOldest {
+		return &candidates[0], nil
+	}
+	return &candidates[len(candidates)-1], nil
+}
+
+// SortedFunctionVersions returns a copy of the function versions ordered by CreatedAt, from the oldest
+// to the newest. Versions created at the same time keep their order.
+func SortedFunctionVersions(versions []NvidiaCloudFunctionInfo) []NvidiaCloudFunctionInfo {
+	sorted := slices.Clone(versions)
+	slices.SortStableFunc(sorted, func(a NvidiaCloudFunctionInfo, b NvidiaCloudFunctionInfo) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return sorted
+}
@@ -0,0 +1,107 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectNvidiaCloudFunctionVersion(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	versions := []NvidiaCloudFunctionInfo{
+		{VersionID: "middle-active", Status: "ACTIVE", CreatedAt: now.Add(-time.Hour)},
+		{VersionID: "newest-inactive", Status: "INACTIVE", CreatedAt: now},
+		{VersionID: "oldest-error", Status: "ERROR", CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	tests := []struct {
+		name          string
+		versions      []NvidiaCloudFunctionInfo
+		selector      string
+		wantVersionID string
+		wantErr       bool
+	}{
+		{
+			name:          "Latest",
+			versions:      versions,
+			selector:      VersionSelectorLatest,
+			wantVersionID: "newest-inactive",
+		},
+		{
+			name:          "LatestActive",
+			versions:      versions,
+			selector:      VersionSelectorLatestActive,
+			wantVersionID: "middle-active",
+		},
+		{
+			name:          "Oldest",
+			versions:      versions,
+			selector:      VersionSelectorOldest,
+			wantVersionID: "oldest-error",
+		},
+		{
+			name:     "NoActiveVersion",
+			versions: versions[1:],
+			selector: VersionSelectorLatestActive,
+		},
+		{
+			name:     "NoVersion",
+			selector: VersionSelectorLatest,
+		},
+		{
+			name:     "UnsupportedSelector",
+			versions: versions,
+			selector: "newest",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectNvidiaCloudFunctionVersion(tt.versions, tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectNvidiaCloudFunctionVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantVersionID == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.wantVersionID, got.VersionID)
+		})
+	}
+}
+
+func TestSortedFunctionVersions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	versions := []NvidiaCloudFunctionInfo{
+		{VersionID: "newest", CreatedAt: now},
+		{VersionID: "oldest", CreatedAt: now.Add(-time.Hour)},
+		{VersionID: "newest-too", CreatedAt: now},
+	}
+
+	got := SortedFunctionVersions(versions)
+
+	versionIDs := make([]string, 0, len(got))
+	for _, v := range got {
+		versionIDs = append(versionIDs, v.VersionID)
+	}
+	assert.Equal(t, []string{"oldest", "newest", "newest-too"}, versionIDs)
+	assert.Equal(t, "newest", versions[0].VersionID, "the versions are sorted in place")
+}